@@ -29,12 +29,16 @@ type Summary struct {
 	TotalUnmanaged int `json:"total_unmanaged"`
 	TotalDeleted   int `json:"total_missing"`
 	TotalManaged   int `json:"total_managed"`
+	// TotalReferenced counts resources referenced by data sources, they are managed outside of the scanned IaC
+	// and are not part of the total of resources
+	TotalReferenced int `json:"total_referenced,omitempty"`
 }
 
 type Analysis struct {
 	unmanaged   []resource.Resource
 	managed     []resource.Resource
 	deleted     []resource.Resource
+	referenced  []resource.Resource
 	differences []Difference
 	summary     Summary
 	alerts      alerter.Alerts
@@ -50,6 +54,7 @@ type serializableAnalysis struct {
 	Managed     []resource.SerializableResource        `json:"managed"`
 	Unmanaged   []resource.SerializableResource        `json:"unmanaged"`
 	Deleted     []resource.SerializableResource        `json:"missing"`
+	Referenced  []resource.SerializableResource        `json:"referenced,omitempty"`
 	Differences []serializableDifference               `json:"differences"`
 	Coverage    int                                    `json:"coverage"`
 	Alerts      map[string][]alerter.SerializableAlert `json:"alerts"`
@@ -66,6 +71,9 @@ func (a Analysis) MarshalJSON() ([]byte, error) {
 	for _, d := range a.deleted {
 		bla.Deleted = append(bla.Deleted, resource.SerializableResource{Resource: d})
 	}
+	for _, r := range a.referenced {
+		bla.Referenced = append(bla.Referenced, resource.SerializableResource{Resource: r})
+	}
 	for _, di := range a.differences {
 		bla.Differences = append(bla.Differences, serializableDifference{
 			Res:       resource.SerializableResource{Resource: di.Res},
@@ -109,6 +117,12 @@ func (a *Analysis) UnmarshalJSON(bytes []byte) error {
 			Type: m.TerraformType(),
 		})
 	}
+	for _, r := range bla.Referenced {
+		a.AddReferenced(resource.SerializedResource{
+			Id:   r.TerraformId(),
+			Type: r.TerraformType(),
+		})
+	}
 	for _, di := range bla.Differences {
 		a.AddDifference(Difference{
 			Res: resource.SerializedResource{
@@ -153,6 +167,12 @@ func (a *Analysis) AddManaged(resources ...resource.Resource) {
 	a.summary.TotalManaged += len(resources)
 }
 
+// AddReferenced adds resources referenced by data sources, they are neither managed nor unmanaged
+func (a *Analysis) AddReferenced(resources ...resource.Resource) {
+	a.referenced = append(a.referenced, resources...)
+	a.summary.TotalReferenced += len(resources)
+}
+
 func (a *Analysis) AddDifference(diffs ...Difference) {
 	a.differences = append(a.differences, diffs...)
 	a.summary.TotalDrifted += len(diffs)
@@ -181,6 +201,10 @@ func (a *Analysis) Deleted() []resource.Resource {
 	return a.deleted
 }
 
+func (a *Analysis) Referenced() []resource.Resource {
+	return a.referenced
+}
+
 func (a *Analysis) Differences() []Difference {
 	return a.differences
 }
@@ -196,6 +220,7 @@ func (a *Analysis) Alerts() alerter.Alerts {
 func (a *Analysis) SortResources() {
 	a.unmanaged = resource.Sort(a.unmanaged)
 	a.deleted = resource.Sort(a.deleted)
+	a.referenced = resource.Sort(a.referenced)
 	a.differences = SortDifferences(a.differences)
 }
 
@@ -64,6 +64,10 @@ func (a Analyzer) Analyze(remoteResources, resourcesFromState []resource.Resourc
 		filteredRemoteResource = append(filteredRemoteResource, remoteRes)
 	}
 
+	// Resources referenced by data sources are matched once managed resources are, a resource can be
+	// both managed by a state and referenced from another one
+	resourcesFromState, referencedResources := splitReferencedResources(resourcesFromState)
+
 	haveComputedDiff := false
 	for _, stateRes := range resourcesFromState {
 		i, remoteRes, found := findCorrespondingRes(filteredRemoteResource, stateRes)
@@ -107,6 +111,15 @@ func (a Analyzer) Analyze(remoteResources, resourcesFromState []resource.Resourc
 		}
 	}
 
+	for _, referencedRes := range referencedResources {
+		i, remoteRes, found := findCorrespondingRes(filteredRemoteResource, referencedRes)
+		if !found {
+			continue
+		}
+		filteredRemoteResource = removeResourceByIndex(i, filteredRemoteResource)
+		analysis.AddReferenced(remoteRes)
+	}
+
 	if a.hasUnmanagedSecurityGroupRules(filteredRemoteResource) {
 		a.alerter.SendAlert("", newUnmanagedSecurityGroupRulesAlert())
 	}
@@ -127,6 +140,19 @@ func (a Analyzer) Analyze(remoteResources, resourcesFromState []resource.Resourc
 	return analysis, nil
 }
 
+func splitReferencedResources(resources []resource.Resource) ([]resource.Resource, []resource.Resource) {
+	others := make([]resource.Resource, 0, len(resources))
+	referenced := make([]resource.Resource, 0)
+	for _, res := range resources {
+		if _, ok := res.(resource.ReferencedResource); ok {
+			referenced = append(referenced, res)
+			continue
+		}
+		others = append(others, res)
+	}
+	return others, referenced
+}
+
 func findCorrespondingRes(resources []resource.Resource, res resource.Resource) (int, resource.Resource, bool) {
 	for i, r := range resources {
 		if resource.IsSameResource(res, r) {
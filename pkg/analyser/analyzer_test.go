@@ -170,6 +170,58 @@ func TestAnalyze(t *testing.T) {
 			},
 			hasDrifted: true,
 		},
+		{
+			name: "TestReferencedResource",
+			iac: []resource.Resource{
+				&testresource.FakeResource{
+					Id: "foobar",
+				},
+				resource.ReferencedResource{
+					SerializedResource: resource.SerializedResource{Id: "foobar", Type: "FakeResource"},
+				},
+				resource.ReferencedResource{
+					SerializedResource: resource.SerializedResource{Id: "shared", Type: "FakeResource"},
+				},
+				resource.ReferencedResource{
+					SerializedResource: resource.SerializedResource{Id: "deleted", Type: "FakeResource"},
+				},
+			},
+			cloud: []resource.Resource{
+				&testresource.FakeResource{
+					Id: "foobar",
+				},
+				&testresource.FakeResource{
+					Id: "shared",
+				},
+				&testresource.FakeResource{
+					Id: "unmanaged",
+				},
+			},
+			expected: Analysis{
+				managed: []resource.Resource{
+					&testresource.FakeResource{
+						Id: "foobar",
+					},
+				},
+				unmanaged: []resource.Resource{
+					&testresource.FakeResource{
+						Id: "unmanaged",
+					},
+				},
+				referenced: []resource.Resource{
+					&testresource.FakeResource{
+						Id: "shared",
+					},
+				},
+				summary: Summary{
+					TotalResources:  2,
+					TotalManaged:    1,
+					TotalUnmanaged:  1,
+					TotalReferenced: 1,
+				},
+			},
+			hasDrifted: true,
+		},
 		{
 			name: "TestDiff",
 			iac: []resource.Resource{
@@ -1035,6 +1087,16 @@ func TestAnalyze(t *testing.T) {
 				}
 			}
 
+			referencedChanges, err := differ.Diff(result.Referenced(), c.expected.Referenced())
+			if err != nil {
+				t.Fatalf("Unable to compare %+v", err)
+			}
+			if len(referencedChanges) > 0 {
+				for _, change := range referencedChanges {
+					t.Errorf("%+v", change)
+				}
+			}
+
 			diffChanges, err := differ.Diff(result.Differences(), c.expected.Differences())
 			if err != nil {
 				t.Fatalf("Unable to compare %+v", err)
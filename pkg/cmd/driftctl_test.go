@@ -99,7 +99,7 @@ func TestDriftctlCmd_Scan(t *testing.T) {
 			env: map[string]string{
 				"DCTL_FROM": "test",
 			},
-			err: fmt.Errorf("Unable to parse from flag 'test': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://"),
+			err: fmt.Errorf("Unable to parse from flag 'test': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+gs://,tfstate+azurerm://,tfstate+tfcloud://,tfstate+consul://,tfstate+stdin://,tfstate+git://,tfdir://,tfplan://,cfn://,pulumi://,tfhcl://,terragrunt://"),
 		},
 		{
 			env: map[string]string{
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform/providers"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac"
+	globaloutput "github.com/cloudskiff/driftctl/pkg/output"
+	"github.com/cloudskiff/driftctl/pkg/remote"
+	"github.com/cloudskiff/driftctl/pkg/terraform"
+)
+
+type LintOptions struct {
+	To              string
+	NoSchema        bool
+	DriftignorePath string
+}
+
+func NewLintCmd() *cobra.Command {
+	opts := &LintOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check .driftignore file",
+		Long:  "Check that every rule of the .driftignore file targets a known resource type and field path",
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !remote.IsSupported(opts.To) {
+				return errors.Errorf(
+					"unsupported cloud provider '%s'\nValid values are: %s",
+					opts.To,
+					strings.Join(remote.GetSupportedRemotes(), ","),
+				)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return lintRun(cmd, opts)
+		},
+	}
+
+	fl := cmd.Flags()
+	supportedRemotes := remote.GetSupportedRemotes()
+	fl.StringVarP(
+		&opts.To,
+		"to",
+		"t",
+		supportedRemotes[0],
+		"Cloud provider whose schema is used to check field paths\n"+
+			"Accepted values are: "+strings.Join(supportedRemotes, ",")+"\n",
+	)
+	fl.BoolVar(&opts.NoSchema,
+		"no-schema",
+		false,
+		"Only check resource types, field paths are not checked against the provider schema",
+	)
+	fl.StringVar(&opts.DriftignorePath,
+		"driftignore",
+		".driftignore",
+		"Path of the .driftignore file to check",
+	)
+
+	return cmd
+}
+
+func lintRun(cmd *cobra.Command, opts *LintOptions) error {
+	file, err := os.Open(opts.DriftignorePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	schemas := make(map[string]providers.Schema)
+	if !opts.NoSchema {
+		providerLibrary := terraform.NewProviderLibrary()
+		defer providerLibrary.Cleanup()
+
+		// Only schemas are needed, the provider is started without credentials
+		err := remote.LoadSchema(opts.To, providerLibrary, globaloutput.NewProgress())
+		if err != nil {
+			return err
+		}
+		for _, providerName := range []string{terraform.AWS, terraform.GITHUB} {
+			provider := providerLibrary.Provider(providerName)
+			if provider == nil {
+				continue
+			}
+			for ty, schema := range provider.Schema() {
+				schemas[ty] = schema
+			}
+		}
+	}
+
+	linter := filter.NewDriftIgnoreLinter(iac.SupportedResourceTypes(), schemas)
+	lintErrors, err := linter.Lint(file)
+	if err != nil {
+		return err
+	}
+
+	for _, lintErr := range lintErrors {
+		logrus.WithFields(logrus.Fields{
+			"line":    lintErr.Line,
+			"content": lintErr.Content,
+			"file":    opts.DriftignorePath,
+		}).Debug("Invalid .driftignore rule")
+		fmt.Fprintln(cmd.OutOrStdout(), lintErr.Error())
+	}
+
+	if len(lintErrors) > 0 {
+		return errors.Errorf("found %d invalid rule(s) in %s", len(lintErrors), opts.DriftignorePath)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudskiff/driftctl/test"
+)
+
+func TestLintCmd_Driftignore(t *testing.T) {
+	cases := []struct {
+		name       string
+		args       []string
+		wantOutput string
+		wantErr    string
+	}{
+		{
+			name: "test valid driftignore file",
+			args: []string{"lint", "--no-schema", "--driftignore", "testdata/valid.driftignore"},
+		},
+		{
+			name:       "test invalid driftignore file",
+			args:       []string{"lint", "--no-schema", "--driftignore", "testdata/invalid.driftignore"},
+			wantOutput: "unknown_type.foo",
+			wantErr:    "found 1 invalid rule(s) in testdata/invalid.driftignore",
+		},
+		{
+			name:    "test missing driftignore file",
+			args:    []string{"lint", "--no-schema", "--driftignore", "testdata/missing.driftignore"},
+			wantErr: "open testdata/missing.driftignore: no such file or directory",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			rootCmd := &cobra.Command{Use: "root"}
+			rootCmd.AddCommand(NewLintCmd())
+
+			output, err := test.Execute(rootCmd, tt.args...)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Expected error '%s', got '%v'", tt.wantErr, err)
+			}
+			if !strings.Contains(output, tt.wantOutput) {
+				t.Errorf("Expected '%s' in output, got '%s'", tt.wantOutput, output)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"os/signal"
 	"strings"
@@ -16,8 +17,10 @@ import (
 	cmderrors "github.com/cloudskiff/driftctl/pkg/cmd/errors"
 	"github.com/cloudskiff/driftctl/pkg/cmd/scan/output"
 	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac"
 	"github.com/cloudskiff/driftctl/pkg/iac/config"
 	"github.com/cloudskiff/driftctl/pkg/iac/supplier"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state"
 	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
 	globaloutput "github.com/cloudskiff/driftctl/pkg/output"
 	"github.com/cloudskiff/driftctl/pkg/remote"
@@ -67,6 +70,18 @@ func NewScanCmd() *cobra.Command {
 					return errors.Wrap(err, "unable to parse filter expression")
 				}
 				opts.Filter = expr
+				opts.FilterOnAnalysis = filter.IsAnalysisExpression(filterFlag)
+				opts.TypeFilter = filter.NewExpressionTypeFilter(filterFlag)
+			}
+
+			onlyTypes, _ := cmd.Flags().GetStringSlice("only-types")
+			excludeTypes, _ := cmd.Flags().GetStringSlice("exclude-types")
+			if len(onlyTypes) > 0 || len(excludeTypes) > 0 {
+				selection, err := parseTypeSelection(onlyTypes, excludeTypes)
+				if err != nil {
+					return err
+				}
+				opts.TypeSelection = selection
 			}
 
 			opts.Quiet, _ = cmd.Flags().GetBool("quiet")
@@ -93,7 +108,22 @@ func NewScanCmd() *cobra.Command {
 			"Examples : \n"+
 			"  - Type == 'aws_s3_bucket' (will filter only s3 buckets)\n"+
 			"  - Type =='aws_s3_bucket && Id != 'my_bucket' (excludes s3 bucket 'my_bucket')\n"+
-			"  - Attr.Tags.Terraform == 'true' (include only resources that have Tag Terraform equal to 'true')\n",
+			"  - Attr.Tags.Terraform == 'true' (include only resources that have Tag Terraform equal to 'true')\n"+
+			"  - Category == 'unmanaged' (include only unmanaged resources, categories are managed, unmanaged, missing and referenced)\n"+
+			"  - Changelog[?Type == 'update'] (include only resources with updated fields)\n"+
+			"  - Changelog[?contains(Path, 'Tags')] (include only resources with drifts on tags)\n",
+	)
+	fl.StringSlice(
+		"only-types",
+		[]string{},
+		"Only scan resources of those types, wildcards are accepted\n"+
+			"Example: --only-types aws_route53_*,aws_s3_bucket\n",
+	)
+	fl.StringSlice(
+		"exclude-types",
+		[]string{},
+		"Do not scan resources of those types, wildcards are accepted\n"+
+			"Example: --exclude-types aws_iam_*\n",
 	)
 	fl.StringP(
 		"output",
@@ -125,11 +155,60 @@ func NewScanCmd() *cobra.Command {
 		"Use those HTTP headers to query the provided URL.\n"+
 			"Only used with tfstate+http(s) backend for now.\n",
 	)
+	fl.StringVar(&opts.BackendOptions.TFCloudToken,
+		"tfc-token",
+		"",
+		"Terraform Cloud / Enterprise API token.\n"+
+			"Defaults to TFE_TOKEN, then to the token of the API host in terraform CLI credentials.\n"+
+			"Only used with tfstate+tfcloud backend.\n",
+	)
+	fl.StringVar(&opts.BackendOptions.TFCloudEndpoint,
+		"tfc-endpoint",
+		backend.DefaultTFCloudEndpoint,
+		"Terraform Cloud / Enterprise API endpoint.\n"+
+			"Only used with tfstate+tfcloud backend.\n",
+	)
+	fl.StringVar(&opts.BackendOptions.GSEndpoint,
+		"gs-endpoint",
+		"",
+		"Google Cloud Storage API endpoint, e.g. a private service connect endpoint.\n"+
+			"Only used with tfstate+gs backend.\n",
+	)
+	fl.StringSliceVar(&opts.BackendOptions.AgeIdentityFiles,
+		"tfstate-age-identity",
+		[]string{},
+		"Age identity files used to decrypt age encrypted states.\n"+
+			"Gzip and zstd compressed states are always supported.\n",
+	)
+	fl.IntVar(&opts.BackendOptions.Parallelism,
+		"tfstate-parallelism",
+		backend.DefaultParallelism,
+		"Number of states read at the same time when a source enumerates several states.\n",
+	)
+	fl.BoolVar(&opts.BackendOptions.DataSources,
+		"tfstate-data-sources",
+		false,
+		"Report resources read by data sources of states as referenced, they are managed outside of scanned IaC\n"+
+			"and are not reported as not covered by IaC.\n",
+	)
+	fl.StringVar(&opts.BackendOptions.LockTable,
+		"tfstate-lock-table",
+		"",
+		"DynamoDB table where terraform stores state locks, an alert is raised for states being applied.\n"+
+			"Only used with tfstate+s3 backend.\n",
+	)
 	fl.BoolVar(&opts.StrictMode,
 		"strict",
 		false,
 		"Includes cloud provider service-linked roles (disabled by default)",
 	)
+	fl.BoolVar(&opts.BackendOptions.ContinueOnError,
+		"continue-on-state-error",
+		false,
+		"Skip IaC sources and states that cannot be read and continue the scan, skipped ones are reported as alerts.\n"+
+			"Applies to every source given with --from. Resources managed by a skipped source may be reported\n"+
+			"as not covered by IaC.\n",
+	)
 
 	return cmd
 }
@@ -142,6 +221,15 @@ func scanRun(opts *pkg.ScanOptions) error {
 
 	alerter := alerter.NewAlerter()
 	providerLibrary := terraform.NewProviderLibrary()
+	typeFilters := []filter.TypeFilter{filter.NewDriftIgnore()}
+	if opts.TypeFilter != nil {
+		typeFilters = append(typeFilters, opts.TypeFilter)
+	}
+	var stateTypeFilter filter.TypeFilter
+	if opts.TypeSelection != nil {
+		typeFilters = append(typeFilters, opts.TypeSelection)
+		stateTypeFilter = pkg.NewStateTypeFilter(opts.TypeSelection)
+	}
 	supplierLibrary := resource.NewSupplierLibrary()
 
 	progress := globaloutput.NewProgress()
@@ -158,9 +246,9 @@ func scanRun(opts *pkg.ScanOptions) error {
 		logrus.Trace("Exited")
 	}()
 
-	scanner := pkg.NewScanner(supplierLibrary.Suppliers(), alerter)
+	scanner := pkg.NewScanner(pkg.FilterSuppliers(supplierLibrary.Suppliers(), typeFilters), alerter)
 
-	iacSupplier, err := supplier.GetIACSupplier(opts.From, providerLibrary, opts.BackendOptions)
+	iacSupplier, err := supplier.GetIACSupplier(opts.From, providerLibrary, opts.BackendOptions, stateTypeFilter, alerter)
 	if err != nil {
 		return err
 	}
@@ -195,13 +283,35 @@ func scanRun(opts *pkg.ScanOptions) error {
 	return nil
 }
 
+func parseTypeSelection(onlyTypes, excludeTypes []string) (*filter.TypeSelection, error) {
+	supportedTypes := iac.SupportedResourceTypes()
+	for _, pattern := range append(onlyTypes, excludeTypes...) {
+		patternSelection := filter.NewTypeSelection([]string{pattern}, nil)
+		matchSupportedType := false
+		for _, ty := range supportedTypes {
+			if patternSelection.IsTypeSelected(ty) {
+				matchSupportedType = true
+				break
+			}
+		}
+		if !matchSupportedType {
+			return nil, errors.Errorf("Unable to parse types, '%s' does not match any supported resource type", pattern)
+		}
+	}
+	return filter.NewTypeSelection(onlyTypes, excludeTypes), nil
+}
+
 func parseFromFlag(from []string) ([]config.SupplierConfig, error) {
 
 	configs := make([]config.SupplierConfig, 0, len(from))
 
+	// Standard input does not need any path, and can only be read once
+	stdinScheme := fmt.Sprintf("%s+%s", state.TerraformStateReaderSupplier, backend.BackendKeyStdin)
+	stdinRead := false
+
 	for _, flag := range from {
 		schemePath := strings.Split(flag, "://")
-		if len(schemePath) != 2 || schemePath[1] == "" || schemePath[0] == "" {
+		if len(schemePath) != 2 || (schemePath[1] == "" && schemePath[0] != stdinScheme) || schemePath[0] == "" {
 			return nil, errors.Wrapf(
 				cmderrors.NewUsageError(
 					fmt.Sprintf(
@@ -246,6 +356,18 @@ func parseFromFlag(from []string) ([]config.SupplierConfig, error) {
 		backendString := ""
 		if len(supplierBackend) == 2 {
 			backendString = supplierBackend[1]
+			if supplierKey != state.TerraformStateReaderSupplier {
+				return nil, errors.Wrapf(
+					cmderrors.NewUsageError(
+						fmt.Sprintf(
+							"\nAccepted schemes are: %s",
+							strings.Join(supplier.GetSupportedSchemes(), ","),
+						),
+					),
+					"IaC source '%s' does not accept a backend",
+					supplierKey,
+				)
+			}
 			if !backend.IsSupported(backendString) {
 				return nil, errors.Wrapf(
 					cmderrors.NewUsageError(
@@ -260,16 +382,70 @@ func parseFromFlag(from []string) ([]config.SupplierConfig, error) {
 			}
 		}
 
-		configs = append(configs, config.SupplierConfig{
+		supplierConfig := config.SupplierConfig{
 			Key:     supplierKey,
 			Backend: backendString,
 			Path:    path,
-		})
+		}
+
+		if backendString == backend.BackendKeyStdin {
+			if stdinRead {
+				return nil, errors.Errorf("Unable to parse from flag '%s': standard input can only be read once", flag)
+			}
+			stdinRead = true
+		}
+
+		if backendString == backend.BackendKeyS3 {
+			if err := parseWorkspaces(&supplierConfig); err != nil {
+				return nil, errors.Wrapf(err, "Unable to parse from flag '%s'", flag)
+			}
+		}
+
+		configs = append(configs, supplierConfig)
 	}
 
 	return configs, nil
 }
 
+// parseWorkspaces extracts terraform workspaces from the query of an s3 path
+// e.g. bucket/path/to/state.tfstate?workspace=prod&workspace=staging or ?workspace=* for every workspace
+func parseWorkspaces(supplierConfig *config.SupplierConfig) error {
+	i := strings.Index(supplierConfig.Path, "?")
+	if i < 0 {
+		return nil
+	}
+
+	query, err := url.ParseQuery(supplierConfig.Path[i+1:])
+	if err != nil {
+		return err
+	}
+	supplierConfig.Path = supplierConfig.Path[:i]
+
+	for key, values := range query {
+		switch key {
+		case "workspace":
+			for _, value := range values {
+				for _, workspace := range strings.Split(value, ",") {
+					if workspace == "" {
+						return errors.New("workspace name cannot be empty")
+					}
+					supplierConfig.Workspaces = append(supplierConfig.Workspaces, workspace)
+				}
+			}
+		case "workspace_key_prefix":
+			supplierConfig.WorkspaceKeyPrefix = query.Get(key)
+		default:
+			return errors.Errorf("unsupported parameter '%s', accepted parameters are: workspace,workspace_key_prefix", key)
+		}
+	}
+
+	if supplierConfig.WorkspaceKeyPrefix != "" && len(supplierConfig.Workspaces) == 0 {
+		return errors.New("workspace_key_prefix requires at least one workspace")
+	}
+
+	return nil
+}
+
 func parseOutputFlag(out string) (*output.OutputConfig, error) {
 	schemeOpts := strings.Split(out, "://")
 	if len(schemeOpts) < 2 || schemeOpts[0] == "" {
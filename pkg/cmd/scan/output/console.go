@@ -60,6 +60,17 @@ func (c *Console) Write(analysis *analyser.Analysis) error {
 		}
 	}
 
+	if analysis.Summary().TotalReferenced > 0 {
+		fmt.Printf("Found resources referenced by data sources and managed outside of IaC sources:\n")
+		referencedByType := groupByType(analysis.Referenced())
+		for ty, resources := range referencedByType {
+			fmt.Printf("  %s:\n", ty)
+			for _, res := range resources {
+				fmt.Printf("    - %s\n", res.TerraformId())
+			}
+		}
+	}
+
 	if analysis.Summary().TotalDrifted > 0 {
 		fmt.Printf("Found changed resources:\n")
 		for _, difference := range analysis.Differences() {
@@ -155,6 +166,9 @@ func (c Console) writeSummary(analysis *analyser.Analysis) {
 		}
 		fmt.Printf(" - %s changed outside of IaC\n", boldWriter.Sprintf("%s/%d", drifted, analysis.Summary().TotalManaged))
 	}
+	if analysis.Summary().TotalReferenced > 0 {
+		fmt.Printf(" - %s referenced by data sources\n", boldWriter.Sprintf("%d", analysis.Summary().TotalReferenced))
+	}
 	if analysis.IsSync() {
 		fmt.Println(color.GreenString("Congrats! Your infrastructure is fully in sync."))
 	}
@@ -41,6 +41,8 @@ func TestScanCmd_Valid(t *testing.T) {
 		{args: []string{"scan", "--to", "aws+tf", "--from", "tfstate+https://github.com/state.tfstate"}},
 		{args: []string{"scan", "--filter", "Type=='aws_s3_bucket'"}},
 		{args: []string{"scan", "--strict"}},
+		{args: []string{"scan", "--only-types", "aws_route53_*,aws_s3_bucket"}},
+		{args: []string{"scan", "--exclude-types", "aws_iam_*"}},
 	}
 
 	for _, tt := range cases {
@@ -69,15 +71,18 @@ func TestScanCmd_Invalid(t *testing.T) {
 		{args: []string{"scan", "-f"}, expected: `flag needs an argument: 'f' in -f`},
 		{args: []string{"scan", "--from"}, expected: `flag needs an argument: --from`},
 		{args: []string{"scan", "--from"}, expected: `flag needs an argument: --from`},
-		{args: []string{"scan", "--from", "tosdgjhgsdhgkjs"}, expected: "Unable to parse from flag 'tosdgjhgsdhgkjs': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://"},
-		{args: []string{"scan", "--from", "://"}, expected: "Unable to parse from flag '://': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://"},
-		{args: []string{"scan", "--from", "://test"}, expected: "Unable to parse from flag '://test': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://"},
-		{args: []string{"scan", "--from", "tosdgjhgsdhgkjs://"}, expected: "Unable to parse from flag 'tosdgjhgsdhgkjs://': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://"},
-		{args: []string{"scan", "--from", "terraform+foo+bar://test"}, expected: "Unable to parse from scheme 'terraform+foo+bar': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://"},
-		{args: []string{"scan", "--from", "unsupported://test"}, expected: "Unsupported IaC source 'unsupported': \nAccepted values are: tfstate"},
-		{args: []string{"scan", "--from", "tfstate+foobar://test"}, expected: "Unsupported IaC backend 'foobar': \nAccepted values are: s3,http,https"},
-		{args: []string{"scan", "--from", "tfstate:///tmp/test", "--from", "tfstate+toto://test"}, expected: "Unsupported IaC backend 'toto': \nAccepted values are: s3,http,https"},
+		{args: []string{"scan", "--from", "tosdgjhgsdhgkjs"}, expected: "Unable to parse from flag 'tosdgjhgsdhgkjs': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+gs://,tfstate+azurerm://,tfstate+tfcloud://,tfstate+consul://,tfstate+stdin://,tfstate+git://,tfdir://,tfplan://,cfn://,pulumi://,tfhcl://,terragrunt://"},
+		{args: []string{"scan", "--from", "://"}, expected: "Unable to parse from flag '://': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+gs://,tfstate+azurerm://,tfstate+tfcloud://,tfstate+consul://,tfstate+stdin://,tfstate+git://,tfdir://,tfplan://,cfn://,pulumi://,tfhcl://,terragrunt://"},
+		{args: []string{"scan", "--from", "://test"}, expected: "Unable to parse from flag '://test': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+gs://,tfstate+azurerm://,tfstate+tfcloud://,tfstate+consul://,tfstate+stdin://,tfstate+git://,tfdir://,tfplan://,cfn://,pulumi://,tfhcl://,terragrunt://"},
+		{args: []string{"scan", "--from", "tosdgjhgsdhgkjs://"}, expected: "Unable to parse from flag 'tosdgjhgsdhgkjs://': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+gs://,tfstate+azurerm://,tfstate+tfcloud://,tfstate+consul://,tfstate+stdin://,tfstate+git://,tfdir://,tfplan://,cfn://,pulumi://,tfhcl://,terragrunt://"},
+		{args: []string{"scan", "--from", "terraform+foo+bar://test"}, expected: "Unable to parse from scheme 'terraform+foo+bar': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+gs://,tfstate+azurerm://,tfstate+tfcloud://,tfstate+consul://,tfstate+stdin://,tfstate+git://,tfdir://,tfplan://,cfn://,pulumi://,tfhcl://,terragrunt://"},
+		{args: []string{"scan", "--from", "unsupported://test"}, expected: "Unsupported IaC source 'unsupported': \nAccepted values are: tfstate,tfdir,tfplan,cfn,pulumi,tfhcl,terragrunt"},
+		{args: []string{"scan", "--from", "tfstate+foobar://test"}, expected: "Unsupported IaC backend 'foobar': \nAccepted values are: s3,http,https,gs,azurerm,tfcloud,consul,stdin,git"},
+		{args: []string{"scan", "--from", "tfstate:///tmp/test", "--from", "tfstate+toto://test"}, expected: "Unsupported IaC backend 'toto': \nAccepted values are: s3,http,https,gs,azurerm,tfcloud,consul,stdin,git"},
+		{args: []string{"scan", "--from", "tfdir+s3://test"}, expected: "IaC source 'tfdir' does not accept a backend: \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+gs://,tfstate+azurerm://,tfstate+tfcloud://,tfstate+consul://,tfstate+stdin://,tfstate+git://,tfdir://,tfplan://,cfn://,pulumi://,tfhcl://,terragrunt://"},
 		{args: []string{"scan", "--filter", "Type='test'"}, expected: "unable to parse filter expression: SyntaxError: Expected tRbracket, received: tUnknown"},
+		{args: []string{"scan", "--only-types", "aws_s3_bukcet"}, expected: "Unable to parse types, 'aws_s3_bukcet' does not match any supported resource type"},
+		{args: []string{"scan", "--exclude-types", "gcp_*"}, expected: "Unable to parse types, 'gcp_*' does not match any supported resource type"},
 	}
 
 	for _, tt := range cases {
@@ -136,6 +141,88 @@ func Test_parseFromFlag(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "test from parsing with workspaces",
+			args: args{
+				from: []string{"tfstate+s3://bucket/path/to/state.tfstate?workspace=prod&workspace=staging"},
+			},
+			want: []config.SupplierConfig{
+				{
+					Key:        "tfstate",
+					Backend:    "s3",
+					Path:       "bucket/path/to/state.tfstate",
+					Workspaces: []string{"prod", "staging"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "test from parsing with every workspace and key prefix",
+			args: args{
+				from: []string{"tfstate+s3://bucket/path/to/state.tfstate?workspace=*&workspace_key_prefix=workspaces"},
+			},
+			want: []config.SupplierConfig{
+				{
+					Key:                "tfstate",
+					Backend:            "s3",
+					Path:               "bucket/path/to/state.tfstate",
+					Workspaces:         []string{"*"},
+					WorkspaceKeyPrefix: "workspaces",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "test from parsing with unsupported parameter",
+			args: args{
+				from: []string{"tfstate+s3://bucket/path/to/state.tfstate?foo=bar"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "test from parsing with key prefix and no workspace",
+			args: args{
+				from: []string{"tfstate+s3://bucket/path/to/state.tfstate?workspace_key_prefix=workspaces"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "test from parsing with stdin and git revision",
+			args: args{
+				from: []string{"tfstate+stdin://", "tfstate+git://../infra@v1.2.0:states/prod.tfstate"},
+			},
+			want: []config.SupplierConfig{
+				{
+					Key:     "tfstate",
+					Backend: "stdin",
+					Path:    "",
+				},
+				{
+					Key:     "tfstate",
+					Backend: "git",
+					Path:    "../infra@v1.2.0:states/prod.tfstate",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "test from parsing with stdin read twice",
+			args: args{
+				from: []string{"tfstate+stdin://", "tfstate+stdin://"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "test from parsing with empty path",
+			args: args{
+				from: []string{"tfstate+git://"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
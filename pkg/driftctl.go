@@ -23,19 +23,27 @@ type ScanOptions struct {
 	To             string
 	Output         output.OutputConfig
 	Filter         *jmespath.JMESPath
+	TypeFilter     filter.TypeFilter
+	TypeSelection  *filter.TypeSelection
 	Quiet          bool
 	BackendOptions *backend.Options
 	StrictMode     bool
+
+	// FilterOnAnalysis applies Filter on analysis results instead of scanned resources,
+	// for expressions using the Category or Changelog of resources
+	FilterOnAnalysis bool
 }
 
 type DriftCTL struct {
-	remoteSupplier  resource.Supplier
-	iacSupplier     resource.Supplier
-	alerter         alerter.AlerterInterface
-	analyzer        analyser.Analyzer
-	filter          *jmespath.JMESPath
-	resourceFactory resource.ResourceFactory
-	strictMode      bool
+	remoteSupplier   resource.Supplier
+	iacSupplier      resource.Supplier
+	alerter          alerter.AlerterInterface
+	analyzer         analyser.Analyzer
+	filter           *jmespath.JMESPath
+	filterOnAnalysis bool
+	typeSelection    *filter.TypeSelection
+	resourceFactory  resource.ResourceFactory
+	strictMode       bool
 }
 
 func NewDriftCTL(remoteSupplier resource.Supplier, iacSupplier resource.Supplier, alerter *alerter.Alerter, resFactory resource.ResourceFactory, opts *ScanOptions) *DriftCTL {
@@ -45,6 +53,8 @@ func NewDriftCTL(remoteSupplier resource.Supplier, iacSupplier resource.Supplier
 		alerter,
 		analyser.NewAnalyzer(alerter),
 		opts.Filter,
+		opts.FilterOnAnalysis,
+		opts.TypeSelection,
 		resFactory,
 		opts.StrictMode,
 	}
@@ -84,13 +94,28 @@ func (d DriftCTL) Run() (*analyser.Analysis, error) {
 		)
 	}
 
+	// Resources only known by their identifier (e.g. CloudFormation stack resources or resources referenced
+	// by data sources) have no attribute for middlewares to process, they are kept aside and only used to
+	// match remote resources
+	resourcesFromState, identifiedResources := splitIdentifiedResources(resourcesFromState)
+	scannedResources := make([]resource.Resource, len(remoteResources))
+	copy(scannedResources, remoteResources)
+
 	logrus.Debug("Ready to run middlewares")
 	err = middleware.Execute(&remoteResources, &resourcesFromState)
 	if err != nil {
 		return nil, err
 	}
+	remoteResources = restoreIdentifiedResources(scannedResources, remoteResources, identifiedResources)
+	resourcesFromState = append(resourcesFromState, identifiedResources...)
+
+	// Resources of other types may have been retrieved for middlewares, they are not part of the results
+	if d.typeSelection != nil {
+		remoteResources = selectResources(remoteResources, d.typeSelection)
+		resourcesFromState = selectResources(resourcesFromState, d.typeSelection)
+	}
 
-	if d.filter != nil {
+	if d.filter != nil && !d.filterOnAnalysis {
 		engine := filter.NewFilterEngine(d.filter)
 		remoteResources, err = engine.Run(remoteResources)
 		if err != nil {
@@ -105,12 +130,23 @@ func (d DriftCTL) Run() (*analyser.Analysis, error) {
 	logrus.Debug("Checking for driftignore")
 	driftIgnore := filter.NewDriftIgnore()
 
-	analysis, err := d.analyzer.Analyze(remoteResources, resourcesFromState, driftIgnore)
+	var analysisFilter analyser.Filter = driftIgnore
+	if iacFieldFilter, ok := d.iacSupplier.(resource.FieldFilterSupplier); ok {
+		analysisFilter = fieldFilter{driftIgnore, iacFieldFilter}
+	}
+
+	analysis, err := d.analyzer.Analyze(remoteResources, resourcesFromState, analysisFilter)
 
 	if err != nil {
 		return nil, err
 	}
 
+	// Expressions using resource category or changelog are applied on analysis results
+	if d.filter != nil && d.filterOnAnalysis {
+		engine := filter.NewFilterEngine(d.filter)
+		return engine.RunOnAnalysis(&analysis)
+	}
+
 	return &analysis, nil
 }
 
@@ -144,3 +180,73 @@ func (d DriftCTL) scan() (remoteResources []resource.Resource, resourcesFromStat
 
 	return remoteResources, resourcesFromState, err
 }
+
+func selectResources(resources []resource.Resource, selection *filter.TypeSelection) []resource.Resource {
+	selected := make([]resource.Resource, 0, len(resources))
+	for _, res := range resources {
+		if selection.IsTypeSelected(res.TerraformType()) {
+			selected = append(selected, res)
+		}
+	}
+	return selected
+}
+
+func splitIdentifiedResources(resources []resource.Resource) ([]resource.Resource, []resource.Resource) {
+	typed := make([]resource.Resource, 0, len(resources))
+	identified := make([]resource.Resource, 0)
+	for _, res := range resources {
+		switch res.(type) {
+		case resource.SerializedResource, resource.ReferencedResource:
+			identified = append(identified, res)
+			continue
+		}
+		typed = append(typed, res)
+	}
+	return typed, identified
+}
+
+// restoreIdentifiedResources adds back remote resources removed by middlewares when they are identified in IaC.
+// Middlewares ignore resources existing by default (e.g. default VPC) when they are not found in IaC,
+// as they do not see identified resources, those declared in a stack would otherwise be reported as missing
+func restoreIdentifiedResources(scanned, remaining, identified []resource.Resource) []resource.Resource {
+	if len(identified) == 0 {
+		return remaining
+	}
+
+	identifiedKeys := make(map[string]struct{}, len(identified))
+	for _, res := range identified {
+		identifiedKeys[resource.Key(res)] = struct{}{}
+	}
+	remainingKeys := make(map[string]struct{}, len(remaining))
+	for _, res := range remaining {
+		remainingKeys[resource.Key(res)] = struct{}{}
+	}
+
+	for _, res := range scanned {
+		key := resource.Key(res)
+		if _, exists := remainingKeys[key]; exists {
+			continue
+		}
+		if _, exists := identifiedKeys[key]; !exists {
+			continue
+		}
+		logrus.WithFields(logrus.Fields{
+			"id":   res.TerraformId(),
+			"type": res.TerraformType(),
+		}).Debug("Keeping remote resource ignored by middlewares as it is identified in IaC")
+		remaining = append(remaining, res)
+		remainingKeys[key] = struct{}{}
+	}
+	return remaining
+}
+
+// fieldFilter ignores fields the IaC supplier cannot tell, e.g. plan attributes unknown until apply,
+// along with fields ignored in .driftignore
+type fieldFilter struct {
+	*filter.DriftIgnore
+	supplier resource.FieldFilterSupplier
+}
+
+func (f fieldFilter) IsFieldIgnored(res resource.Resource, path []string) bool {
+	return f.DriftIgnore.IsFieldIgnored(res, path) || f.supplier.IsFieldIgnored(res, path)
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/cloudskiff/driftctl/pkg/alerter"
 	"github.com/cloudskiff/driftctl/pkg/analyser"
 	filter2 "github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/pulumi"
 	"github.com/cloudskiff/driftctl/pkg/resource"
 	"github.com/cloudskiff/driftctl/pkg/resource/aws"
 	"github.com/cloudskiff/driftctl/pkg/terraform"
@@ -26,12 +28,25 @@ type TestCase struct {
 	stateResources  []resource.Resource
 	remoteResources []resource.Resource
 	filter          string
+	typeSelection   *filter2.TypeSelection
 	mocks           func(factory resource.ResourceFactory)
 	assert          func(result *test.ScanResult, err error)
 }
 
 type TestCases []TestCase
 
+func readPulumiStack(t *testing.T, path string) []resource.Resource {
+	reader, err := pulumi.NewReader(config.SupplierConfig{Key: pulumi.PulumiSupplier, Path: path}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resources, err := reader.Resources()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resources
+}
+
 func runTest(t *testing.T, cases TestCases) {
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
@@ -65,7 +80,9 @@ func runTest(t *testing.T, cases TestCases) {
 			}
 
 			driftctl := pkg.NewDriftCTL(remoteSupplier, stateSupplier, testAlerter, resourceFactory, &pkg.ScanOptions{
-				Filter: filter,
+				Filter:           filter,
+				FilterOnAnalysis: filter2.IsAnalysisExpression(c.filter),
+				TypeSelection:    c.typeSelection,
 			})
 
 			analysis, err := driftctl.Run()
@@ -229,6 +246,66 @@ func TestDriftctlRun_BasicBehavior(t *testing.T) {
 				})
 			},
 		},
+		{
+			name: "we should have managed resource known only by its identifier",
+			stateResources: []resource.Resource{
+				resource.SerializedResource{
+					Id:   "bucket",
+					Type: aws.AwsS3BucketResourceType,
+				},
+			},
+			remoteResources: []resource.Resource{
+				&aws.AwsS3Bucket{
+					Id:     "bucket",
+					Bucket: awssdk.String("bucket"),
+				},
+			},
+			assert: func(result *test.ScanResult, err error) {
+				result.AssertManagedCount(1)
+				result.AssertUnmanagedCount(0)
+				result.AssertDriftCountTotal(0)
+			},
+		},
+		{
+			name: "we should have default resources declared in a stack as managed",
+			stateResources: []resource.Resource{
+				resource.SerializedResource{Id: "vpc-default", Type: aws.AwsDefaultVpcResourceType},
+				resource.SerializedResource{Id: "subnet-default", Type: aws.AwsDefaultSubnetResourceType},
+				resource.SerializedResource{Id: "rtb-default", Type: aws.AwsDefaultRouteTableResourceType},
+				resource.SerializedResource{Id: "sg-default", Type: aws.AwsDefaultSecurityGroupResourceType},
+				resource.SerializedResource{Id: "https://sqs.eu-west-3.amazonaws.com/123456789012/queue", Type: aws.AwsSqsQueuePolicyResourceType},
+				resource.SerializedResource{Id: "r-rtb-default1080289494", Type: aws.AwsRouteResourceType},
+			},
+			remoteResources: []resource.Resource{
+				&aws.AwsDefaultVpc{Id: "vpc-default"},
+				&aws.AwsDefaultVpc{Id: "vpc-other-region"},
+				&aws.AwsDefaultSubnet{Id: "subnet-default"},
+				&aws.AwsDefaultRouteTable{Id: "rtb-default"},
+				&aws.AwsDefaultSecurityGroup{Id: "sg-default"},
+				&aws.AwsSqsQueuePolicy{Id: "https://sqs.eu-west-3.amazonaws.com/123456789012/queue", Policy: awssdk.String("")},
+				&aws.AwsRoute{Id: "r-rtb-default1080289494", RouteTableId: awssdk.String("rtb-default"), Origin: awssdk.String("CreateRouteTable")},
+			},
+			assert: func(result *test.ScanResult, err error) {
+				result.AssertManagedCount(6)
+				result.AssertDeletedCount(0)
+				result.AssertUnmanagedCount(0)
+			},
+		},
+		{
+			name:           "we should have default resources declared in a pulumi stack as managed",
+			stateResources: readPulumiStack(t, "iac/pulumi/testdata/default-resources.json"),
+			remoteResources: []resource.Resource{
+				&aws.AwsDefaultVpc{Id: "vpc-default"},
+				&aws.AwsDefaultSubnet{Id: "subnet-default"},
+				&aws.AwsDefaultRouteTable{Id: "rtb-default"},
+				&aws.AwsDefaultSecurityGroup{Id: "sg-default"},
+			},
+			assert: func(result *test.ScanResult, err error) {
+				result.AssertManagedCount(4)
+				result.AssertDeletedCount(0)
+				result.AssertUnmanagedCount(0)
+			},
+		},
 	}
 
 	runTest(t, cases)
@@ -236,6 +313,31 @@ func TestDriftctlRun_BasicBehavior(t *testing.T) {
 
 func TestDriftctlRun_BasicFilter(t *testing.T) {
 	cases := TestCases{
+		{
+			name: "test type selection",
+			stateResources: []resource.Resource{
+				testresource.FakeResource{
+					Id:   "res1",
+					Type: "not-selected",
+				},
+			},
+			remoteResources: []resource.Resource{
+				testresource.FakeResource{
+					Id:   "res2",
+					Type: "not-selected",
+				},
+				testresource.FakeResource{
+					Id:   "res3",
+					Type: "selected",
+				},
+			},
+			typeSelection: filter2.NewTypeSelection([]string{"sel*"}, nil),
+			assert: func(result *test.ScanResult, err error) {
+				result.AssertDeletedCount(0)
+				result.AssertUnmanagedCount(1)
+				result.AssertResourceUnmanaged("res3", "selected")
+			},
+		},
 		{
 			name:           "test filtering on Type",
 			stateResources: []resource.Resource{},
@@ -299,6 +401,145 @@ func TestDriftctlRun_BasicFilter(t *testing.T) {
 				result.AssertResourceUnmanaged("res1", "filtered")
 			},
 		},
+		{
+			name: "test filtering on attribute is applied before analysis",
+			stateResources: []resource.Resource{
+				testresource.FakeResource{
+					Id:   "res1",
+					Type: "filtered",
+					CtyVal: func() *cty.Value {
+						v := cty.ObjectVal(map[string]cty.Value{
+							"test_field": cty.StringVal("value to filter on"),
+						})
+						return &v
+					}(),
+				},
+			},
+			remoteResources: []resource.Resource{
+				testresource.FakeResource{
+					Id:   "res1",
+					Type: "filtered",
+					CtyVal: func() *cty.Value {
+						v := cty.ObjectVal(map[string]cty.Value{
+							"test_field": cty.StringVal("another value"),
+						})
+						return &v
+					}(),
+				},
+			},
+			filter: "Attr.test_field=='value to filter on'",
+			assert: func(result *test.ScanResult, err error) {
+				result.AssertManagedCount(0)
+				result.AssertDeletedCount(1)
+				result.AssertResourceDeleted("res1", "filtered")
+			},
+		},
+		{
+			name: "test filtering on type only reports resources of this type",
+			stateResources: []resource.Resource{
+				&aws.AwsS3Bucket{
+					Id:     "foo",
+					Bucket: awssdk.String("foo"),
+					Policy: awssdk.String("{\"Id\":\"foo\"}"),
+				},
+			},
+			remoteResources: []resource.Resource{
+				&aws.AwsS3Bucket{
+					Id:     "foo",
+					Bucket: awssdk.String("foo"),
+				},
+				&aws.AwsS3Bucket{
+					Id:     "bar",
+					Bucket: awssdk.String("bar"),
+				},
+				&aws.AwsS3BucketPolicy{
+					Id:     "foo",
+					Bucket: awssdk.String("foo"),
+					Policy: awssdk.String("{\"Id\":\"foo\"}"),
+				},
+			},
+			mocks: func(factory resource.ResourceFactory) {
+				foo := cty.ObjectVal(map[string]cty.Value{
+					"id":     cty.StringVal("foo"),
+					"bucket": cty.StringVal("foo"),
+					"policy": cty.StringVal("{\"Id\":\"foo\"}"),
+				})
+				factory.(*terraform.MockResourceFactory).On("CreateResource", mock.Anything, "aws_s3_bucket_policy").Times(1).Return(&foo, nil)
+			},
+			filter: "Type=='aws_s3_bucket'",
+			assert: func(result *test.ScanResult, err error) {
+				result.AssertManagedCount(1)
+				result.AssertUnmanagedCount(1)
+				result.AssertResourceUnmanaged("bar", "aws_s3_bucket")
+				result.AssertDeletedCount(0)
+				result.AssertDriftCountTotal(0)
+			},
+		},
+		{
+			name: "test filtering on category",
+			stateResources: []resource.Resource{
+				testresource.FakeResource{
+					Id:   "res1",
+					Type: "filtered",
+				},
+			},
+			remoteResources: []resource.Resource{
+				testresource.FakeResource{
+					Id:   "res1",
+					Type: "filtered",
+				},
+				testresource.FakeResource{
+					Id:   "res2",
+					Type: "filtered",
+				},
+			},
+			filter: "Category=='managed'",
+			assert: func(result *test.ScanResult, err error) {
+				result.AssertManagedCount(1)
+				result.AssertUnmanagedCount(0)
+				result.AssertInfrastructureIsInSync()
+			},
+		},
+		{
+			name: "test filtering on changelog",
+			stateResources: []resource.Resource{
+				testresource.FakeResource{
+					Id:     "res1",
+					FooBar: "foo",
+				},
+				testresource.FakeResource{
+					Id: "res2",
+					Tags: map[string]string{
+						"tag1": "foo",
+					},
+				},
+			},
+			remoteResources: []resource.Resource{
+				testresource.FakeResource{
+					Id:     "res1",
+					FooBar: "bar",
+				},
+				testresource.FakeResource{
+					Id: "res2",
+					Tags: map[string]string{
+						"tag1": "bar",
+					},
+				},
+			},
+			filter: "Changelog[?contains(Path, 'Tags')]",
+			assert: func(result *test.ScanResult, err error) {
+				result.AssertManagedCount(1)
+				result.AssertDriftCountTotal(1)
+				result.AssertResourceHasDrift("res2", "FakeResource", analyser.Change{
+					Change: diff.Change{
+						Type: diff.UPDATE,
+						Path: []string{"Tags", "tag1"},
+						From: "foo",
+						To:   "bar",
+					},
+				})
+			},
+		},
 	}
 
 	runTest(t, cases)
@@ -358,6 +599,36 @@ func TestDriftctlRun_Middlewares(t *testing.T) {
 				})
 			},
 		},
+		{
+			name: "test instance eip middleware when only instances are selected",
+			stateResources: []resource.Resource{
+				&aws.AwsInstance{
+					Id:       "i-0123456789abcdef0",
+					PublicIp: awssdk.String("1.2.3.4"),
+				},
+				&aws.AwsEip{
+					Id:       "eipalloc-0123456789abcdef0",
+					Instance: awssdk.String("i-0123456789abcdef0"),
+				},
+			},
+			remoteResources: []resource.Resource{
+				&aws.AwsInstance{
+					Id:       "i-0123456789abcdef0",
+					PublicIp: awssdk.String("5.6.7.8"),
+				},
+				&aws.AwsEip{
+					Id:       "eipalloc-0123456789abcdef0",
+					Instance: awssdk.String("i-0123456789abcdef0"),
+				},
+			},
+			typeSelection: filter2.NewTypeSelection([]string{"aws_instance"}, nil),
+			assert: func(result *test.ScanResult, err error) {
+				result.AssertManagedCount(1)
+				result.AssertDeletedCount(0)
+				result.AssertUnmanagedCount(0)
+				result.AssertResourceHasNoDrift("i-0123456789abcdef0", "aws_instance")
+			},
+		},
 		{
 			name: "test instance block device middleware",
 			stateResources: []resource.Resource{
@@ -8,13 +8,15 @@ import (
 	"strings"
 
 	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/jmespath/go-jmespath"
 	"github.com/sirupsen/logrus"
 )
 
 type DriftIgnore struct {
-	resExclusionList         map[string]struct{} // map[type.id] exists to ignore
-	resExclusionWildcardList map[string]struct{} // map[type.id] exists with wildcard to ignore
-	driftExclusionList       map[string][]string // map[type.id] contains path for drift to ignore
+	resExclusionList         map[string]struct{}  // map[type.id] exists to ignore
+	resExclusionWildcardList map[string]struct{}  // map[type.id] exists with wildcard to ignore
+	driftExclusionList       map[string][]string  // map[type.id] contains path for drift to ignore
+	resExclusionExprList     []*jmespath.JMESPath // expressions matching resources to ignore
 }
 
 func NewDriftIgnore() *DriftIgnore {
@@ -22,6 +24,7 @@ func NewDriftIgnore() *DriftIgnore {
 		resExclusionList:         map[string]struct{}{},
 		resExclusionWildcardList: map[string]struct{}{},
 		driftExclusionList:       map[string][]string{},
+		resExclusionExprList:     []*jmespath.JMESPath{},
 	}
 	err := d.readIgnoreFile()
 	if err != nil {
@@ -46,6 +49,21 @@ func (r *DriftIgnore) readIgnoreFile() error {
 			}).Debug("Skipped comment or empty line")
 			continue
 		}
+		if isDriftIgnoreExpression(line) { // We want to ignore resources matching an expression ([?Attr.tags.foo=='bar'])
+			expr, err := jmespath.Compile(line)
+			if err != nil {
+				logrus.WithFields(logrus.Fields{
+					"line":    strconv.Itoa(lineNumber),
+					"content": line,
+				}).Warnf("unable to parse expression: %s", err)
+				continue
+			}
+			logrus.WithFields(logrus.Fields{
+				"expression": line,
+			}).Debug("Found ignore expression rule in .driftignore")
+			r.resExclusionExprList = append(r.resExclusionExprList, expr)
+			continue
+		}
 		typeVal := readDriftIgnoreLine(line)
 		nbArgs := len(typeVal)
 		if nbArgs < 2 {
@@ -103,6 +121,32 @@ func (r *DriftIgnore) IsResourceIgnored(res resource.Resource) bool {
 			return true
 		}
 	}
+	for _, expr := range r.resExclusionExprList {
+		match, err := NewFilterEngine(expr).Match(res)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"type": res.TerraformType(),
+				"id":   res.TerraformId(),
+			}).Debugf("Unable to evaluate ignore expression: %s", err)
+			continue
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTypeIgnored returns true when a rule ignores every resource of the given type (type.*)
+func (r *DriftIgnore) IsTypeIgnored(ty string) bool {
+	for resExclusion := range r.resExclusionWildcardList {
+		if !strings.HasSuffix(resExclusion, ".*") {
+			continue
+		}
+		if wildcardMatchChecker(ty, strings.TrimSuffix(resExclusion, ".*")) {
+			return true
+		}
+	}
 	return false
 }
 
@@ -143,6 +187,11 @@ RuleCheck:
 	return false
 }
 
+// An expression rule is a JMESPath filter projection, e.g. [?Attr.tags.ManagedBy=='cdk']
+func isDriftIgnoreExpression(line string) bool {
+	return strings.HasPrefix(line, "[?") && strings.HasSuffix(line, "]")
+}
+
 //Check two strings recursively, pattern can contain wildcard
 func wildcardMatchChecker(str, pattern string) bool {
 	if str == "" && pattern == "" {
@@ -0,0 +1,143 @@
+package filter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/providers"
+	"github.com/jmespath/go-jmespath"
+)
+
+type DriftIgnoreLintError struct {
+	Line    int
+	Content string
+	Reason  string
+}
+
+func (e DriftIgnoreLintError) Error() string {
+	return fmt.Sprintf("line %d: %s (%s)", e.Line, e.Reason, e.Content)
+}
+
+type DriftIgnoreLinter struct {
+	types   []string
+	schemas map[string]providers.Schema
+}
+
+// NewDriftIgnoreLinter creates a linter checking rules against the given resource types.
+// Field paths are only checked for types that have an entry in schemas.
+func NewDriftIgnoreLinter(types []string, schemas map[string]providers.Schema) *DriftIgnoreLinter {
+	return &DriftIgnoreLinter{
+		types:   types,
+		schemas: schemas,
+	}
+}
+
+func (l *DriftIgnoreLinter) Lint(reader io.Reader) ([]DriftIgnoreLintError, error) {
+	lintErrors := make([]DriftIgnoreLintError, 0)
+
+	scanner := bufio.NewScanner(reader)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if reason := l.lintLine(line); reason != "" {
+			lintErrors = append(lintErrors, DriftIgnoreLintError{
+				Line:    lineNumber,
+				Content: line,
+				Reason:  reason,
+			})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lintErrors, nil
+}
+
+func (l *DriftIgnoreLinter) lintLine(line string) string {
+	if isDriftIgnoreExpression(line) {
+		if _, err := jmespath.Compile(line); err != nil {
+			return fmt.Sprintf("invalid expression: %s", err)
+		}
+		return ""
+	}
+
+	typeVal := readDriftIgnoreLine(line)
+	if len(typeVal) < 2 {
+		return fmt.Sprintf("invalid length, got %d expected >= 2", len(typeVal))
+	}
+
+	ty := typeVal[0]
+	if !l.isKnownType(ty) {
+		return fmt.Sprintf("unknown resource type '%s'", ty)
+	}
+
+	if len(typeVal) == 2 {
+		return ""
+	}
+
+	schema, exists := l.schemas[ty]
+	if !exists || schema.Block == nil {
+		return ""
+	}
+
+	path := typeVal[2:]
+	if !isPathInBlock(schema.Block, path) {
+		return fmt.Sprintf("unknown field path '%s' for resource type '%s'", strings.Join(path, "."), ty)
+	}
+
+	return ""
+}
+
+func (l *DriftIgnoreLinter) isKnownType(ty string) bool {
+	for _, t := range l.types {
+		if t == ty || wildcardMatchChecker(t, ty) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPathInBlock checks a drift path against a schema block.
+// Path components are compared ignoring case and underscores, as drifts are reported
+// with resource struct field names (BucketPrefix) while schema uses attribute names (bucket_prefix)
+func isPathInBlock(block *configschema.Block, path []string) bool {
+	if len(path) == 0 {
+		return true
+	}
+
+	field := path[0]
+	if strings.Contains(field, "*") {
+		return true
+	}
+	// Skip list indexes of nested blocks
+	if _, err := strconv.Atoi(field); err == nil {
+		return isPathInBlock(block, path[1:])
+	}
+
+	for name := range block.Attributes {
+		if normalizeFieldName(name) == normalizeFieldName(field) {
+			// We cannot go deeper as attributes may be maps or objects with arbitrary keys
+			return true
+		}
+	}
+
+	for name, nested := range block.BlockTypes {
+		if normalizeFieldName(name) == normalizeFieldName(field) {
+			return isPathInBlock(&nested.Block, path[1:])
+		}
+	}
+
+	return false
+}
+
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
@@ -0,0 +1,94 @@
+package filter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/providers"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestDriftIgnoreLinter_Lint(t *testing.T) {
+	types := []string{"aws_s3_bucket", "aws_s3_bucket_policy", "aws_instance"}
+	schemas := map[string]providers.Schema{
+		"aws_s3_bucket": {
+			Block: &configschema.Block{
+				Attributes: map[string]*configschema.Attribute{
+					"bucket_prefix": {Type: cty.String},
+					"tags":          {Type: cty.Map(cty.String)},
+				},
+				BlockTypes: map[string]*configschema.NestedBlock{
+					"cors_rule": {
+						Nesting: configschema.NestingList,
+						Block: configschema.Block{
+							Attributes: map[string]*configschema.Attribute{
+								"allowed_headers": {Type: cty.List(cty.String)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    []DriftIgnoreLintError
+	}{
+		{
+			name:    "empty file",
+			content: "",
+			want:    []DriftIgnoreLintError{},
+		},
+		{
+			name: "valid rules",
+			content: strings.Join([]string{
+				"# this is a comment",
+				"",
+				"aws_s3_bucket.my-bucket",
+				"aws_s3_*.*",
+				"aws_s3_bucket.my-bucket.BucketPrefix",
+				"aws_s3_bucket.*.Tags.Environment",
+				"aws_s3_bucket.*.CorsRule.0.AllowedHeaders",
+				"aws_s3_bucket.*.cors_rule.*",
+				"aws_instance.i-123456.Whatever",
+				"[?Attr.tags.ManagedBy=='cdk']",
+			}, "\n"),
+			want: []DriftIgnoreLintError{},
+		},
+		{
+			name: "invalid rules",
+			content: strings.Join([]string{
+				"aws_s3_bucket",
+				"aws_s3_bukcet.my-bucket",
+				"aws_s3_bucket.my-bucket.BucketPrefx",
+				"aws_s3_bucket.my-bucket.CorsRule.0.Foo",
+				"aws_vpc*.*",
+				"[?Attr.tags.ManagedBy==]",
+			}, "\n"),
+			want: []DriftIgnoreLintError{
+				{Line: 1, Content: "aws_s3_bucket", Reason: "invalid length, got 1 expected >= 2"},
+				{Line: 2, Content: "aws_s3_bukcet.my-bucket", Reason: "unknown resource type 'aws_s3_bukcet'"},
+				{Line: 3, Content: "aws_s3_bucket.my-bucket.BucketPrefx", Reason: "unknown field path 'BucketPrefx' for resource type 'aws_s3_bucket'"},
+				{Line: 4, Content: "aws_s3_bucket.my-bucket.CorsRule.0.Foo", Reason: "unknown field path 'CorsRule.0.Foo' for resource type 'aws_s3_bucket'"},
+				{Line: 5, Content: "aws_vpc*.*", Reason: "unknown resource type 'aws_vpc*'"},
+				{Line: 6, Content: "[?Attr.tags.ManagedBy==]", Reason: "invalid expression: SyntaxError: Invalid token: tRbracket"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewDriftIgnoreLinter(types, schemas)
+			got, err := l.Lint(strings.NewReader(tt.content))
+			if err != nil {
+				t.Fatalf("Lint() unexpected error = %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lint() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
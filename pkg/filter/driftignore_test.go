@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
 
 	resource2 "github.com/cloudskiff/driftctl/test/resource"
 
@@ -151,6 +152,59 @@ func TestDriftIgnore_IsResourceIgnored(t *testing.T) {
 				true,
 			},
 		},
+		{
+			name: "drift_ignore_expressions",
+			resources: []resource.Resource{
+				&resource2.FakeResource{
+					Type: "type1",
+					Id:   "id1",
+					CtyVal: func() *cty.Value {
+						v := cty.ObjectVal(map[string]cty.Value{
+							"tags": cty.MapVal(map[string]cty.Value{
+								"ManagedBy": cty.StringVal("cdk"),
+							}),
+						})
+						return &v
+					}(),
+				},
+				&resource2.FakeResource{
+					Type: "type1",
+					Id:   "id2",
+					CtyVal: func() *cty.Value {
+						v := cty.ObjectVal(map[string]cty.Value{
+							"tags": cty.MapVal(map[string]cty.Value{
+								"ManagedBy": cty.StringVal("terraform"),
+							}),
+						})
+						return &v
+					}(),
+				},
+				&resource2.FakeResource{
+					Type: "type1",
+					Id:   "id3",
+				},
+				&resource2.FakeResource{
+					Type: "type2",
+					Id:   "tmp-id1",
+				},
+				&resource2.FakeResource{
+					Type: "type2",
+					Id:   "id1",
+				},
+				&resource2.FakeResource{
+					Type: "type3",
+					Id:   "id1",
+				},
+			},
+			want: []bool{
+				true,
+				false,
+				false,
+				true,
+				false,
+				true,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -356,3 +410,42 @@ func Test_escapableSplit(t *testing.T) {
 		})
 	}
 }
+
+func TestDriftIgnore_IsTypeIgnored(t *testing.T) {
+	tests := []struct {
+		name  string
+		types []string
+		want  []bool
+	}{
+		{
+			name:  "drift_ignore_no_file",
+			types: []string{"type1"},
+			want:  []bool{false},
+		},
+		{
+			name:  "drift_ignore_valid",
+			types: []string{"wildcard_resource", "ignored_resource", "resource_type"},
+			want:  []bool{true, false, false},
+		},
+		{
+			name:  "drift_ignore_wildcard",
+			types: []string{"type1", "type2", "type3"},
+			want:  []bool{false, false, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cwd, _ := os.Getwd()
+			defer func() { _ = os.Chdir(cwd) }()
+			if err := os.Chdir(path.Join("testdata", tt.name)); err != nil {
+				t.Fatal(err)
+			}
+			r := NewDriftIgnore()
+			got := make([]bool, 0, len(tt.want))
+			for _, ty := range tt.types {
+				got = append(got, r.IsTypeIgnored(ty))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -7,9 +7,33 @@ import (
 )
 
 func BuildExpression(expressionStr string) (*jmespath.JMESPath, error) {
-	expr, err := jmespath.Compile(fmt.Sprintf("[?%s]", expressionStr))
+	expr, err := jmespath.Compile(filterExpression(expressionStr))
 	if err != nil {
 		return nil, err
 	}
 	return expr, nil
 }
+
+// IsAnalysisExpression tells whether an expression uses fields only known once resources are analyzed,
+// i.e. the Category or the Changelog of resources
+func IsAnalysisExpression(expressionStr string) bool {
+	root, ok := parseAST(filterExpression(expressionStr))
+	return ok && hasAnalysisField(root)
+}
+
+func hasAnalysisField(node astNode) bool {
+	if node.is(astField) && (node.value == "Category" || node.value == "Changelog") {
+		return true
+	}
+	for _, child := range node.children {
+		if hasAnalysisField(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterExpression builds a filter projection on resources from a condition
+func filterExpression(expressionStr string) string {
+	return fmt.Sprintf("[?%s]", expressionStr)
+}
@@ -3,11 +3,13 @@ package filter
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/jmespath/go-jmespath"
 	"github.com/zclconf/go-cty/cty"
 	ctyjson "github.com/zclconf/go-cty/cty/json"
 
+	"github.com/cloudskiff/driftctl/pkg/analyser"
 	"github.com/cloudskiff/driftctl/pkg/resource"
 )
 
@@ -19,10 +21,27 @@ func NewFilterEngine(expr *jmespath.JMESPath) *FilterEngine {
 	return &FilterEngine{expr: expr}
 }
 
+const (
+	CategoryManaged   = "managed"
+	CategoryUnmanaged = "unmanaged"
+	CategoryMissing   = "missing"
+	// CategoryReferenced is the category of resources referenced by data sources
+	CategoryReferenced = "referenced"
+)
+
 type filtrableResource struct {
-	Attr     interface{}
-	Res      resource.Resource
-	Type, Id string
+	Attr      interface{}
+	Res       resource.Resource
+	Type, Id  string
+	Category  string
+	Changelog interface{}
+}
+
+type filtrableChange struct {
+	Type     string
+	Path     []string
+	From, To interface{}
+	Computed bool
 }
 
 func (e *FilterEngine) Run(resources []resource.Resource) ([]resource.Resource, error) {
@@ -34,40 +53,160 @@ func (e *FilterEngine) Run(resources []resource.Resource) ([]resource.Resource,
 	// We convert a list of resource in a list of DTO to run JMESPath on
 	filtrableResources := make([]filtrableResource, 0, len(resources))
 	for _, res := range resources {
-		// We need to serialize all attributes to untyped interface from JMESPath to work
-		// map[string]string and map[string]SomeThing will not work without it
-		// https://github.com/jmespath/go-jmespath/issues/22
-		ctyVal := res.CtyValue()
-		if ctyVal == nil {
-			ctyVal = &cty.EmptyObjectVal
-		}
-		bytes, _ := ctyjson.Marshal(*ctyVal, ctyVal.Type())
-		var attrs interface{}
-		_ = json.Unmarshal(bytes, &attrs)
-		f := filtrableResource{
-			Attr: attrs,
-			Res:  res,
-			Id:   res.TerraformId(),
-			Type: res.TerraformType(),
-		}
 		filtrableResources = append(
 			filtrableResources,
-			f,
+			newFiltrableResource(res),
 		)
 	}
 
-	// Do the filter
-	JMESPathOutput, err := e.expr.Search(filtrableResources)
+	return e.search(filtrableResources)
+}
+
+// RunOnAnalysis filters the result of an analysis.
+// In addition to Type, Id and Attr, expressions can use the Category of a resource
+// (managed, unmanaged, missing or referenced) and the Changelog of a drifted resource.
+func (e *FilterEngine) RunOnAnalysis(analysis *analyser.Analysis) (*analyser.Analysis, error) {
+
+	if e.expr == nil {
+		return nil, errors.New("expression is nil")
+	}
+
+	changelogs := make(map[string]analyser.Changelog, len(analysis.Differences()))
+	for _, difference := range analysis.Differences() {
+		changelogs[resource.Key(difference.Res)] = difference.Changelog
+	}
+
+	filtrableResources := make([]filtrableResource, 0, analysis.Summary().TotalResources)
+	for _, res := range analysis.Managed() {
+		f := newFiltrableResource(res)
+		f.Category = CategoryManaged
+		if changelog, exists := changelogs[resource.Key(res)]; exists {
+			changes := make([]filtrableChange, 0, len(changelog))
+			for _, change := range changelog {
+				changes = append(changes, filtrableChange{
+					Type:     change.Type,
+					Path:     change.Path,
+					From:     change.From,
+					To:       change.To,
+					Computed: change.Computed,
+				})
+			}
+			// Changelog is serialized to untyped interface for the same reason as attributes
+			bytes, _ := json.Marshal(changes)
+			_ = json.Unmarshal(bytes, &f.Changelog)
+		}
+		filtrableResources = append(filtrableResources, f)
+	}
+	for _, res := range analysis.Unmanaged() {
+		f := newFiltrableResource(res)
+		f.Category = CategoryUnmanaged
+		filtrableResources = append(filtrableResources, f)
+	}
+	for _, res := range analysis.Deleted() {
+		f := newFiltrableResource(res)
+		f.Category = CategoryMissing
+		filtrableResources = append(filtrableResources, f)
+	}
+
+	for _, res := range analysis.Referenced() {
+		f := newFiltrableResource(res)
+		f.Category = CategoryReferenced
+		filtrableResources = append(filtrableResources, f)
+	}
+
+	results, err := e.searchFiltrable(filtrableResources)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rebuild an analysis with filtered results
+	filtered := analyser.Analysis{}
+	for _, f := range results {
+		switch f.Category {
+		case CategoryManaged:
+			filtered.AddManaged(f.Res)
+			if changelog, exists := changelogs[resource.Key(f.Res)]; exists {
+				filtered.AddDifference(analyser.Difference{
+					Res:       f.Res,
+					Changelog: changelog,
+				})
+			}
+		case CategoryUnmanaged:
+			filtered.AddUnmanaged(f.Res)
+		case CategoryMissing:
+			filtered.AddDeleted(f.Res)
+		case CategoryReferenced:
+			filtered.AddReferenced(f.Res)
+		}
+	}
+	filtered.SortResources()
+	filtered.SetAlerts(analysis.Alerts())
+
+	return &filtered, nil
+}
+
+func (e *FilterEngine) search(filtrableResources []filtrableResource) ([]resource.Resource, error) {
+	filtered, err := e.searchFiltrable(filtrableResources)
 	if err != nil {
 		return nil, err
 	}
 
 	// Convert back filtered results into a resource list
-	filteredRawList := JMESPathOutput.([]interface{})
-	results := make([]resource.Resource, 0, len(filteredRawList))
-	for _, elem := range filteredRawList {
-		results = append(results, elem.(filtrableResource).Res)
+	results := make([]resource.Resource, 0, len(filtered))
+	for _, f := range filtered {
+		results = append(results, f.Res)
+	}
+
+	return results, nil
+}
+
+// searchFiltrable runs the expression, it must result in a list of the given resources
+func (e *FilterEngine) searchFiltrable(filtrableResources []filtrableResource) ([]filtrableResource, error) {
+	JMESPathOutput, err := e.expr.Search(filtrableResources)
+	if err != nil {
+		return nil, err
 	}
 
+	filteredRawList, ok := JMESPathOutput.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("filter expression must result in a list of resources, got %T", JMESPathOutput)
+	}
+	results := make([]filtrableResource, 0, len(filteredRawList))
+	for _, elem := range filteredRawList {
+		f, ok := elem.(filtrableResource)
+		if !ok {
+			return nil, fmt.Errorf("filter expression must result in a list of resources, got an element of type %T", elem)
+		}
+		results = append(results, f)
+	}
 	return results, nil
 }
+
+func newFiltrableResource(res resource.Resource) filtrableResource {
+	// We need to serialize all attributes to untyped interface from JMESPath to work
+	// map[string]string and map[string]SomeThing will not work without it
+	// https://github.com/jmespath/go-jmespath/issues/22
+	// Resources only known by their identifier have no attribute
+	ctyVal := res.CtyValue()
+	if ctyVal == nil || *ctyVal == cty.NilVal {
+		ctyVal = &cty.EmptyObjectVal
+	}
+	bytes, _ := ctyjson.Marshal(*ctyVal, ctyVal.Type())
+	var attrs interface{}
+	_ = json.Unmarshal(bytes, &attrs)
+	return filtrableResource{
+		Attr: attrs,
+		Res:  res,
+		Id:   res.TerraformId(),
+		Type: res.TerraformType(),
+	}
+}
+
+// Match returns true if the given resource is selected by the expression
+func (e *FilterEngine) Match(res resource.Resource) (bool, error) {
+	results, err := e.Run([]resource.Resource{res})
+	if err != nil {
+		return false, err
+	}
+	return len(results) > 0, nil
+}
@@ -5,10 +5,14 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/jmespath/go-jmespath"
+	"github.com/r3labs/diff/v2"
+	"github.com/stretchr/testify/assert"
 	"github.com/zclconf/go-cty/cty"
 
 	testresource "github.com/cloudskiff/driftctl/test/resource"
 
+	"github.com/cloudskiff/driftctl/pkg/analyser"
 	"github.com/cloudskiff/driftctl/pkg/resource"
 )
 
@@ -355,3 +359,210 @@ func TestFilterEngine_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterEngine_Match(t *testing.T) {
+	expr, err := BuildExpression("Type=='filtered_resource'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := NewFilterEngine(expr)
+
+	match, err := e.Match(&testresource.FakeResource{Type: "filtered_resource"})
+	if err != nil || !match {
+		t.Errorf("Match() got = %v, %v, want true", match, err)
+	}
+
+	match, err = e.Match(&testresource.FakeResource{Type: "other_resource"})
+	if err != nil || match {
+		t.Errorf("Match() got = %v, %v, want false", match, err)
+	}
+
+	match, err = e.Match(resource.SerializedResource{Id: "id", Type: "filtered_resource"})
+	if err != nil || !match {
+		t.Errorf("Match() got = %v, %v, want true", match, err)
+	}
+}
+
+func TestFilterEngine_RunOnAnalysisWithIdentifiedResources(t *testing.T) {
+	expr, err := BuildExpression("Type=='filtered_resource' && Attr.foo == null")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	analysis := &analyser.Analysis{}
+	analysis.AddManaged(resource.SerializedResource{Id: "managed", Type: "filtered_resource"})
+	analysis.AddUnmanaged(&testresource.FakeResource{Id: "unmanaged", Type: "filtered_resource"})
+	analysis.AddDeleted(resource.SerializedResource{Id: "missing", Type: "other_resource"})
+	analysis.AddReferenced(resource.ReferencedResource{SerializedResource: resource.SerializedResource{Id: "referenced", Type: "filtered_resource"}})
+
+	got, err := NewFilterEngine(expr).RunOnAnalysis(analysis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []resource.Resource{resource.SerializedResource{Id: "managed", Type: "filtered_resource"}}, got.Managed())
+	assert.Equal(t, []resource.Resource{&testresource.FakeResource{Id: "unmanaged", Type: "filtered_resource"}}, got.Unmanaged())
+	assert.Empty(t, got.Deleted())
+	assert.Equal(t, []resource.Resource{resource.ReferencedResource{SerializedResource: resource.SerializedResource{Id: "referenced", Type: "filtered_resource"}}}, got.Referenced())
+}
+
+func TestFilterEngine_RunOnAnalysis(t *testing.T) {
+	newAnalysis := func() *analyser.Analysis {
+		a := &analyser.Analysis{}
+		a.AddManaged(
+			&testresource.FakeResource{Id: "managed-tags", Type: "type1"},
+			&testresource.FakeResource{Id: "managed-name", Type: "type1"},
+			&testresource.FakeResource{Id: "managed-sync", Type: "type2"},
+		)
+		a.AddDifference(
+			analyser.Difference{
+				Res: &testresource.FakeResource{Id: "managed-tags", Type: "type1"},
+				Changelog: analyser.Changelog{
+					{Change: diff.Change{Type: diff.UPDATE, Path: []string{"Tags", "Env"}, From: "dev", To: "prod"}},
+				},
+			},
+			analyser.Difference{
+				Res: &testresource.FakeResource{Id: "managed-name", Type: "type1"},
+				Changelog: analyser.Changelog{
+					{Change: diff.Change{Type: diff.CREATE, Path: []string{"Name"}, To: "foo"}, Computed: true},
+				},
+			},
+		)
+		a.AddUnmanaged(&testresource.FakeResource{Id: "unmanaged", Type: "type1"})
+		a.AddDeleted(&testresource.FakeResource{Id: "missing", Type: "type2"})
+		a.AddReferenced(&testresource.FakeResource{Id: "referenced", Type: "type1"})
+		return a
+	}
+
+	tests := []struct {
+		name          string
+		expr          string
+		wantManaged   []string
+		wantUnmanaged []string
+		wantDeleted   []string
+		wantDrifted   []string
+		// Resources referenced by data sources are only expected when filtering on them
+		wantReferenced []string
+	}{
+		{
+			name:          "filter on category",
+			expr:          "Category=='unmanaged' || Category=='missing'",
+			wantManaged:   []string{},
+			wantUnmanaged: []string{"unmanaged"},
+			wantDeleted:   []string{"missing"},
+			wantDrifted:   []string{},
+		},
+		{
+			name:           "filter on referenced category",
+			expr:           "Category=='referenced'",
+			wantManaged:    []string{},
+			wantUnmanaged:  []string{},
+			wantDeleted:    []string{},
+			wantDrifted:    []string{},
+			wantReferenced: []string{"referenced"},
+		},
+		{
+			name:          "filter on change type",
+			expr:          "Changelog[?Type=='update']",
+			wantManaged:   []string{"managed-tags"},
+			wantUnmanaged: []string{},
+			wantDeleted:   []string{},
+			wantDrifted:   []string{"managed-tags"},
+		},
+		{
+			name:          "filter on change path",
+			expr:          "Changelog[?contains(Path, 'Name')]",
+			wantManaged:   []string{"managed-name"},
+			wantUnmanaged: []string{},
+			wantDeleted:   []string{},
+			wantDrifted:   []string{"managed-name"},
+		},
+		{
+			name:          "filter on type and category",
+			expr:          "Type=='type2' && Category=='managed'",
+			wantManaged:   []string{"managed-sync"},
+			wantUnmanaged: []string{},
+			wantDeleted:   []string{},
+			wantDrifted:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := BuildExpression(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := NewFilterEngine(expr).RunOnAnalysis(newAnalysis())
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids := func(resources []resource.Resource) []string {
+				res := make([]string, 0, len(resources))
+				for _, r := range resources {
+					res = append(res, r.TerraformId())
+				}
+				return res
+			}
+			drifted := make([]string, 0)
+			for _, d := range got.Differences() {
+				drifted = append(drifted, d.Res.TerraformId())
+			}
+			assert.Equal(t, tt.wantManaged, ids(got.Managed()))
+			assert.Equal(t, tt.wantUnmanaged, ids(got.Unmanaged()))
+			assert.Equal(t, tt.wantDeleted, ids(got.Deleted()))
+			assert.Equal(t, tt.wantDrifted, drifted)
+			assert.ElementsMatch(t, tt.wantReferenced, ids(got.Referenced()))
+			assert.Equal(t, len(tt.wantManaged)+len(tt.wantUnmanaged)+len(tt.wantDeleted), got.Summary().TotalResources)
+		})
+	}
+}
+
+func TestFilterEngine_RunWithNonResourceResults(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		err  string
+	}{
+		{
+			name: "expression resulting in a number",
+			expr: "length(@)",
+			err:  "filter expression must result in a list of resources, got float64",
+		},
+		{
+			name: "expression resulting in a list of ids",
+			expr: "[?Type=='filtered_resource'].Id",
+			err:  "filter expression must result in a list of resources, got an element of type string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewFilterEngine(jmespath.MustCompile(tt.expr))
+
+			_, err := e.Run([]resource.Resource{&testresource.FakeResource{Id: "id", Type: "filtered_resource"}})
+			assert.EqualError(t, err, tt.err)
+
+			analysis := &analyser.Analysis{}
+			analysis.AddManaged(&testresource.FakeResource{Id: "id", Type: "filtered_resource"})
+			_, err = e.RunOnAnalysis(analysis)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestIsAnalysisExpression(t *testing.T) {
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: "Type=='aws_s3_bucket'", want: false},
+		{expr: "Attr.tags.Category=='managed' && Id=='foo'", want: true},
+		{expr: "Type=='aws_s3_bucket' && Category=='managed'", want: true},
+		{expr: "Changelog[?contains(Path, 'Tags')]", want: true},
+		{expr: "!(Category=='unmanaged')", want: true},
+		{expr: "???", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsAnalysisExpression(tt.expr))
+		})
+	}
+}
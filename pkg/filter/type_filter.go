@@ -0,0 +1,208 @@
+package filter
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/jmespath/go-jmespath"
+)
+
+// TypeFilter tells if every resource of a given type is excluded from results.
+// It is used to avoid enumerating resources that would be filtered out anyway.
+type TypeFilter interface {
+	IsTypeIgnored(ty string) bool
+}
+
+type typeMatch int
+
+const (
+	typeMatchUnknown typeMatch = iota
+	typeMatchTrue
+	typeMatchFalse
+)
+
+// ExpressionTypeFilter statically evaluates a filter expression against a resource type.
+// The expression is parsed by the JMESPath parser, only conditions on the Type field are understood
+// (Type == 'x', Type != 'x', starts_with(Type, 'x')) combined with &&, || and !.
+// Every other node is considered as possibly true, so a type is never ignored by mistake.
+type ExpressionTypeFilter struct {
+	condition *astNode
+}
+
+func NewExpressionTypeFilter(expr string) *ExpressionTypeFilter {
+	return &ExpressionTypeFilter{condition: parseFilterCondition(expr)}
+}
+
+// IsTypeIgnored returns true only if the expression can never match a resource of the given type
+func (f *ExpressionTypeFilter) IsTypeIgnored(ty string) bool {
+	if f.condition == nil {
+		return false
+	}
+	return evalTypeCondition(*f.condition, ty) == typeMatchFalse
+}
+
+func evalTypeCondition(node astNode, ty string) typeMatch {
+	switch {
+	case node.is(astOrExpression) && len(node.children) == 2:
+		result := typeMatchFalse
+		for _, child := range node.children {
+			switch evalTypeCondition(child, ty) {
+			case typeMatchTrue:
+				return typeMatchTrue
+			case typeMatchUnknown:
+				result = typeMatchUnknown
+			}
+		}
+		return result
+	case node.is(astAndExpression) && len(node.children) == 2:
+		result := typeMatchTrue
+		for _, child := range node.children {
+			switch evalTypeCondition(child, ty) {
+			case typeMatchFalse:
+				return typeMatchFalse
+			case typeMatchUnknown:
+				result = typeMatchUnknown
+			}
+		}
+		return result
+	case node.is(astNotExpression) && len(node.children) == 1:
+		switch evalTypeCondition(node.children[0], ty) {
+		case typeMatchTrue:
+			return typeMatchFalse
+		case typeMatchFalse:
+			return typeMatchTrue
+		}
+		return typeMatchUnknown
+	case node.is(astComparator) && len(node.children) == 2:
+		return evalTypeComparison(node, ty)
+	case node.is(astFunctionExpression) && node.value == "starts_with" && len(node.children) == 2:
+		prefix, ok := typeOperand(node.children[0], node.children[1])
+		if !ok {
+			return typeMatchUnknown
+		}
+		return toTypeMatch(strings.HasPrefix(ty, prefix))
+	}
+	return typeMatchUnknown
+}
+
+func evalTypeComparison(node astNode, ty string) typeMatch {
+	value, ok := typeOperand(node.children[0], node.children[1])
+	if !ok {
+		value, ok = typeOperand(node.children[1], node.children[0])
+	}
+	if !ok || node.value == nil {
+		return typeMatchUnknown
+	}
+	switch node.value {
+	case equalOperator:
+		return toTypeMatch(ty == value)
+	case notEqualOperator:
+		return toTypeMatch(ty != value)
+	}
+	return typeMatchUnknown
+}
+
+// typeOperand returns the string literal compared to the Type field
+func typeOperand(field, literal astNode) (string, bool) {
+	if !field.is(astField) || field.value != "Type" || !literal.is(astLiteral) {
+		return "", false
+	}
+	value, ok := literal.value.(string)
+	return value, ok
+}
+
+func toTypeMatch(b bool) typeMatch {
+	if b {
+		return typeMatchTrue
+	}
+	return typeMatchFalse
+}
+
+// parseFilterCondition returns the condition of the filter projection built from an expression by BuildExpression.
+// Nil is returned when the expression cannot be parsed, or does not result in a single filter projection.
+func parseFilterCondition(expr string) *astNode {
+	root, ok := parseAST(filterExpression(expr))
+	if !ok || !root.is(astFilterProjection) || len(root.children) != 3 {
+		return nil
+	}
+	if !root.children[0].is(astIdentity) || !root.children[1].is(astIdentity) {
+		return nil
+	}
+	return &root.children[2]
+}
+
+// Comparison operators are not exported by go-jmespath, they are read from parsed comparisons
+var (
+	equalOperator    = comparisonOperator("a == b")
+	notEqualOperator = comparisonOperator("a != b")
+)
+
+func comparisonOperator(expr string) interface{} {
+	node, ok := parseAST(expr)
+	if !ok || !node.is(astComparator) {
+		return nil
+	}
+	return node.value
+}
+
+// astNode is a node of the AST built by the JMESPath parser.
+// go-jmespath does not export fields of nodes, they are read by reflection.
+// Nodes whose fields cannot be read have an unknown type and are never understood.
+type astNode struct {
+	nodeType int64
+	// value is the name of fields and functions, string literals and comparison operators
+	value    interface{}
+	children []astNode
+}
+
+// Node types of go-jmespath have an unexported type
+const (
+	astNodeUnknown        int64 = -1
+	astField                    = int64(jmespath.ASTField)
+	astLiteral                  = int64(jmespath.ASTLiteral)
+	astIdentity                 = int64(jmespath.ASTIdentity)
+	astFilterProjection         = int64(jmespath.ASTFilterProjection)
+	astOrExpression             = int64(jmespath.ASTOrExpression)
+	astAndExpression            = int64(jmespath.ASTAndExpression)
+	astNotExpression            = int64(jmespath.ASTNotExpression)
+	astComparator               = int64(jmespath.ASTComparator)
+	astFunctionExpression       = int64(jmespath.ASTFunctionExpression)
+)
+
+func parseAST(expr string) (astNode, bool) {
+	root, err := jmespath.NewParser().Parse(expr)
+	if err != nil {
+		return astNode{}, false
+	}
+	return newASTNode(reflect.ValueOf(root)), true
+}
+
+func newASTNode(v reflect.Value) astNode {
+	node := astNode{nodeType: astNodeUnknown}
+	if v.Kind() != reflect.Struct {
+		return node
+	}
+	nodeType, value, children := v.FieldByName("nodeType"), v.FieldByName("value"), v.FieldByName("children")
+	if nodeType.Kind() != reflect.Int || !value.IsValid() || children.Kind() != reflect.Slice {
+		return node
+	}
+
+	node.nodeType = nodeType.Int()
+	if value.Kind() == reflect.Interface && !value.IsNil() {
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.String:
+		node.value = value.String()
+	case reflect.Int:
+		node.value = value.Int()
+	}
+	for i := 0; i < children.Len(); i++ {
+		node.children = append(node.children, newASTNode(children.Index(i)))
+	}
+	return node
+}
+
+func (n astNode) is(nodeType int64) bool {
+	return n.nodeType != astNodeUnknown && n.nodeType == nodeType
+}
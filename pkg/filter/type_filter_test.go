@@ -0,0 +1,249 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/r3labs/diff/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	testresource "github.com/cloudskiff/driftctl/test/resource"
+)
+
+func TestExpressionTypeFilter_IsTypeIgnored(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want map[string]bool
+	}{
+		{
+			name: "test type equality",
+			expr: "Type=='aws_s3_bucket'",
+			want: map[string]bool{
+				"aws_s3_bucket":        false,
+				"aws_s3_bucket_policy": true,
+			},
+		},
+		{
+			name: "test type inequality",
+			expr: "Type != 'aws_s3_bucket'",
+			want: map[string]bool{
+				"aws_s3_bucket":        true,
+				"aws_s3_bucket_policy": false,
+			},
+		},
+		{
+			name: "test reversed operands and json literal",
+			expr: "`\"aws_s3_bucket\"` == Type",
+			want: map[string]bool{
+				"aws_s3_bucket": false,
+				"aws_iam_user":  true,
+			},
+		},
+		{
+			name: "test starts_with",
+			expr: "starts_with(Type, 'aws_s3_')",
+			want: map[string]bool{
+				"aws_s3_bucket":        false,
+				"aws_s3_bucket_policy": false,
+				"aws_iam_user":         true,
+			},
+		},
+		{
+			name: "test and with unknown condition",
+			expr: "Type=='aws_s3_bucket' && Id != 'my_bucket'",
+			want: map[string]bool{
+				"aws_s3_bucket": false,
+				"aws_iam_user":  true,
+			},
+		},
+		{
+			name: "test or with unknown condition",
+			expr: "Type=='aws_s3_bucket' || Attr.Tags.Terraform == 'true'",
+			want: map[string]bool{
+				"aws_s3_bucket": false,
+				"aws_iam_user":  false,
+			},
+		},
+		{
+			name: "test or on types",
+			expr: "Type=='aws_s3_bucket' || Type=='aws_iam_user'",
+			want: map[string]bool{
+				"aws_s3_bucket": false,
+				"aws_iam_user":  false,
+				"aws_iam_role":  true,
+			},
+		},
+		{
+			name: "test negation and parentheses",
+			expr: "!(Type=='aws_s3_bucket' || Type=='aws_iam_user') && Category == 'unmanaged'",
+			want: map[string]bool{
+				"aws_s3_bucket": true,
+				"aws_iam_user":  true,
+				"aws_iam_role":  false,
+			},
+		},
+		{
+			name: "test type in nested expression is not the resource type",
+			expr: "Changelog[?Type == 'update']",
+			want: map[string]bool{
+				"aws_s3_bucket": false,
+				"update":        false,
+			},
+		},
+		{
+			name: "test negation binds tighter than comparison",
+			expr: "!Type != 'aws_s3_bucket'",
+			want: map[string]bool{
+				"aws_s3_bucket": false,
+				"aws_iam_user":  false,
+			},
+		},
+		{
+			name: "test negation of function",
+			expr: "!starts_with(Type, 'aws_s3_')",
+			want: map[string]bool{
+				"aws_s3_bucket": true,
+				"aws_iam_user":  false,
+			},
+		},
+		{
+			name: "test pipe applies on whole condition",
+			expr: "Type=='aws_s3_bucket' && Category=='managed' | !@",
+			want: map[string]bool{
+				"aws_s3_bucket": false,
+				"aws_iam_user":  false,
+			},
+		},
+		{
+			name: "test comparison against attribute",
+			expr: "Type == Attr.type && Type != 'aws_s3_bucket'",
+			want: map[string]bool{
+				"aws_s3_bucket": true,
+				"aws_iam_user":  false,
+			},
+		},
+		{
+			name: "test operator in string literal",
+			expr: "Type == 'a && b'",
+			want: map[string]bool{
+				"a && b": false,
+				"a":      true,
+			},
+		},
+		{
+			name: "test unknown function",
+			expr: "contains(Type, 's3')",
+			want: map[string]bool{
+				"aws_s3_bucket": false,
+				"aws_iam_user":  false,
+			},
+		},
+		{
+			name: "test expression escaping the filter projection",
+			expr: "Type=='aws_iam_user'] | [?Type=='aws_s3_bucket'",
+			want: map[string]bool{
+				"aws_s3_bucket": false,
+				"aws_iam_user":  false,
+			},
+		},
+		{
+			name: "test invalid expression",
+			expr: "Type=='aws_s3_bucket' &&",
+			want: map[string]bool{
+				"aws_s3_bucket": false,
+				"aws_iam_user":  false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewExpressionTypeFilter(tt.expr)
+			for ty, want := range tt.want {
+				if got := f.IsTypeIgnored(ty); got != want {
+					t.Errorf("IsTypeIgnored(%s) got = %v, want %v", ty, got, want)
+				}
+			}
+		})
+	}
+}
+
+// Every type matched by the real evaluation of an expression must not be ignored
+func TestExpressionTypeFilter_NeverIgnoresMatchedTypes(t *testing.T) {
+	types := []string{"aws_s3_bucket", "aws_s3_bucket_policy", "aws_iam_user", "update", "managed"}
+	newAnalysis := func() *analyser.Analysis {
+		a := &analyser.Analysis{}
+		for _, ty := range types {
+			attrs := cty.ObjectVal(map[string]cty.Value{
+				"type":   cty.StringVal(ty),
+				"bucket": cty.StringVal("my_bucket"),
+				"tags": cty.ObjectVal(map[string]cty.Value{
+					"Terraform": cty.StringVal("true"),
+				}),
+			})
+			managed := &testresource.FakeResource{Id: "managed", Type: ty, CtyVal: &attrs}
+			a.AddManaged(managed, resource.SerializedResource{Id: ty, Type: ty})
+			a.AddDifference(analyser.Difference{
+				Res: managed,
+				Changelog: analyser.Changelog{
+					{Change: diff.Change{Type: diff.UPDATE, Path: []string{"Tags", "Env"}, From: "dev", To: "prod"}},
+				},
+			})
+			a.AddUnmanaged(&testresource.FakeResource{Id: "my_bucket", Type: ty, CtyVal: &attrs})
+			a.AddDeleted(&testresource.FakeResource{Id: "missing", Type: ty})
+			a.AddReferenced(resource.ReferencedResource{SerializedResource: resource.SerializedResource{Id: "referenced", Type: ty}})
+		}
+		return a
+	}
+
+	expressions := []string{
+		"Type=='aws_s3_bucket'",
+		"Type!='aws_s3_bucket'",
+		"`\"aws_s3_bucket\"` == Type",
+		"starts_with(Type, 'aws_s3_')",
+		"!(Type=='aws_s3_bucket')",
+		"!!(Type=='aws_s3_bucket')",
+		"!Type=='aws_s3_bucket'",
+		"!Type!='aws_s3_bucket'",
+		"!starts_with(Type, 'aws_s3_')",
+		"!(Type=='aws_s3_bucket' || Type=='aws_iam_user') && Category=='unmanaged'",
+		"Type=='aws_s3_bucket' || Attr.tags.Terraform=='true'",
+		"Type=='aws_s3_bucket' || Id=='my_bucket'",
+		"Type=='aws_s3_bucket' && Category=='managed' || Type=='aws_iam_user'",
+		"(Type=='aws_s3_bucket' || Category=='missing') && Id=='missing'",
+		"Type=='aws_s3_bucket' && Category=='managed' | !@",
+		"Type==Attr.type",
+		"Attr.bucket!=Type",
+		"Type==Id",
+		"Type!=Attr.type || Type=='aws_s3_bucket'",
+		"Attr.type=='aws_iam_user'",
+		"Changelog[?Type=='update']",
+		"Type=='managed' || Category=='managed'",
+		"Type > 'aws_s3'",
+		"contains(Type, 's3')",
+		"Type=='aws_s3_bucket' || `true`",
+		"Type=='aws_iam_user'] | [?Type=='aws_s3_bucket'",
+		"Type=='aws_s3_bucket' && (Type=='aws_iam_user' || Attr.type=='aws_iam_user')",
+	}
+	for _, expr := range expressions {
+		t.Run(expr, func(t *testing.T) {
+			compiled, err := BuildExpression(expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := NewFilterEngine(compiled).RunOnAnalysis(newAnalysis())
+			if err != nil {
+				t.Fatal(err)
+			}
+			matched := append(append(append(got.Managed(), got.Unmanaged()...), got.Deleted()...), got.Referenced()...)
+
+			f := NewExpressionTypeFilter(expr)
+			for _, res := range matched {
+				if f.IsTypeIgnored(res.TerraformType()) {
+					t.Errorf("IsTypeIgnored(%s) got = true, but expression matches %s", res.TerraformType(), res.TerraformId())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package filter
+
+// TypeSelection restricts a scan to a set of resource types.
+// Types can contain wildcards, e.g. aws_route53_*
+type TypeSelection struct {
+	only    []string
+	exclude []string
+}
+
+// NewTypeSelection creates a selection of types matching one of only patterns (every type when empty)
+// and none of exclude patterns
+func NewTypeSelection(only, exclude []string) *TypeSelection {
+	return &TypeSelection{
+		only:    only,
+		exclude: exclude,
+	}
+}
+
+func (s *TypeSelection) IsTypeSelected(ty string) bool {
+	for _, pattern := range s.exclude {
+		if wildcardMatchChecker(ty, pattern) {
+			return false
+		}
+	}
+	if len(s.only) == 0 {
+		return true
+	}
+	for _, pattern := range s.only {
+		if wildcardMatchChecker(ty, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *TypeSelection) IsTypeIgnored(ty string) bool {
+	return !s.IsTypeSelected(ty)
+}
@@ -0,0 +1,62 @@
+package filter
+
+import (
+	"testing"
+)
+
+func TestTypeSelection_IsTypeSelected(t *testing.T) {
+	tests := []struct {
+		name    string
+		only    []string
+		exclude []string
+		want    map[string]bool
+	}{
+		{
+			name: "test empty selection",
+			want: map[string]bool{
+				"aws_s3_bucket": true,
+			},
+		},
+		{
+			name: "test only types",
+			only: []string{"aws_route53_*", "aws_s3_bucket"},
+			want: map[string]bool{
+				"aws_route53_zone":     true,
+				"aws_route53_record":   true,
+				"aws_s3_bucket":        true,
+				"aws_s3_bucket_policy": false,
+			},
+		},
+		{
+			name:    "test exclude types",
+			exclude: []string{"aws_iam_*"},
+			want: map[string]bool{
+				"aws_iam_user":  false,
+				"aws_s3_bucket": true,
+			},
+		},
+		{
+			name:    "test exclude takes precedence",
+			only:    []string{"aws_route53_*"},
+			exclude: []string{"aws_route53_health_check"},
+			want: map[string]bool{
+				"aws_route53_zone":         true,
+				"aws_route53_health_check": false,
+				"aws_s3_bucket":            false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewTypeSelection(tt.only, tt.exclude)
+			for ty, want := range tt.want {
+				if got := s.IsTypeSelected(ty); got != want {
+					t.Errorf("IsTypeSelected(%s) got = %v, want %v", ty, got, want)
+				}
+				if got := s.IsTypeIgnored(ty); got == want {
+					t.Errorf("IsTypeIgnored(%s) got = %v, want %v", ty, got, !want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package cloudformation
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+const CloudformationSupplier = "cfn"
+
+const nestedStackResourceType = "AWS::CloudFormation::Stack"
+
+// resourceTypes maps CloudFormation resource types to terraform resource types.
+// Only types whose physical resource id is the terraform resource id are listed.
+var resourceTypes = map[string]string{
+	"AWS::CloudFront::Distribution":   "aws_cloudfront_distribution",
+	"AWS::DynamoDB::Table":            "aws_dynamodb_table",
+	"AWS::EC2::Instance":              "aws_instance",
+	"AWS::EC2::InternetGateway":       "aws_internet_gateway",
+	"AWS::EC2::KeyPair":               "aws_key_pair",
+	"AWS::EC2::NatGateway":            "aws_nat_gateway",
+	"AWS::EC2::RouteTable":            "aws_route_table",
+	"AWS::EC2::SecurityGroup":         "aws_security_group",
+	"AWS::EC2::Subnet":                "aws_subnet",
+	"AWS::EC2::VPC":                   "aws_vpc",
+	"AWS::EC2::Volume":                "aws_ebs_volume",
+	"AWS::ECR::Repository":            "aws_ecr_repository",
+	"AWS::IAM::AccessKey":             "aws_iam_access_key",
+	"AWS::IAM::ManagedPolicy":         "aws_iam_policy",
+	"AWS::IAM::Role":                  "aws_iam_role",
+	"AWS::IAM::User":                  "aws_iam_user",
+	"AWS::KMS::Alias":                 "aws_kms_alias",
+	"AWS::KMS::Key":                   "aws_kms_key",
+	"AWS::Lambda::EventSourceMapping": "aws_lambda_event_source_mapping",
+	"AWS::Lambda::Function":           "aws_lambda_function",
+	"AWS::RDS::DBInstance":            "aws_db_instance",
+	"AWS::RDS::DBSubnetGroup":         "aws_db_subnet_group",
+	"AWS::Route53::HealthCheck":       "aws_route53_health_check",
+	"AWS::Route53::HostedZone":        "aws_route53_zone",
+	"AWS::S3::Bucket":                 "aws_s3_bucket",
+	"AWS::SNS::Subscription":          "aws_sns_topic_subscription",
+	"AWS::SNS::Topic":                 "aws_sns_topic",
+	"AWS::SQS::Queue":                 "aws_sqs_queue",
+}
+
+// stackResource is a resource of a stack, as returned by ListStackResources or DescribeStackResources
+type stackResource struct {
+	LogicalResourceId  string `json:"LogicalResourceId" yaml:"LogicalResourceId"`
+	PhysicalResourceId string `json:"PhysicalResourceId" yaml:"PhysicalResourceId"`
+	ResourceType       string `json:"ResourceType" yaml:"ResourceType"`
+	ResourceStatus     string `json:"ResourceStatus" yaml:"ResourceStatus"`
+}
+
+// stackSnapshot is the output of aws cloudformation list-stack-resources or describe-stack-resources
+type stackSnapshot struct {
+	StackResourceSummaries []stackResource `json:"StackResourceSummaries" yaml:"StackResourceSummaries"`
+	StackResources         []stackResource `json:"StackResources" yaml:"StackResources"`
+}
+
+// CloudformationReader reads resources owned by a CloudFormation stack, either from an exported
+// resource listing (JSON or YAML) or from the CloudFormation API when the path is a stack name or id.
+// Resources only carry their identifier, they are considered managed without comparing attributes.
+type CloudformationReader struct {
+	config     config.SupplierConfig
+	typeFilter filter.TypeFilter
+	client     cloudformationiface.CloudFormationAPI
+}
+
+func NewReader(config config.SupplierConfig, typeFilter filter.TypeFilter) (*CloudformationReader, error) {
+	if config.Path == "" {
+		return nil, errors.New("Unable to read CloudFormation stack, a stack name or a resource listing file must be provided")
+	}
+	return &CloudformationReader{config: config, typeFilter: typeFilter}, nil
+}
+
+// IsSnapshot tells if a path points to an exported resource listing rather than a stack
+func IsSnapshot(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+func (r *CloudformationReader) Resources() ([]resource.Resource, error) {
+	var stackResources []stackResource
+	var err error
+	if IsSnapshot(r.config.Path) {
+		stackResources, err = readSnapshot(r.config.Path)
+	} else {
+		stackResources, err = r.listStackResources(r.config.Path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]resource.Resource, 0, len(stackResources))
+	for _, stackRes := range stackResources {
+		res, ok := r.toResource(stackRes)
+		if !ok {
+			continue
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+func (r *CloudformationReader) toResource(stackRes stackResource) (resource.Resource, bool) {
+	logger := logrus.WithFields(logrus.Fields{
+		"logical_id": stackRes.LogicalResourceId,
+		"type":       stackRes.ResourceType,
+	})
+
+	ty, exists := resourceTypes[stackRes.ResourceType]
+	if !exists {
+		logger.Debug("Ignored CloudFormation resource of unsupported type")
+		return nil, false
+	}
+	if stackRes.PhysicalResourceId == "" || stackRes.ResourceStatus == cloudformation.ResourceStatusDeleteComplete {
+		logger.Debug("Ignored CloudFormation resource that does not exist")
+		return nil, false
+	}
+	if r.typeFilter != nil && r.typeFilter.IsTypeIgnored(ty) {
+		return nil, false
+	}
+
+	logger.WithFields(logrus.Fields{
+		"id": stackRes.PhysicalResourceId,
+	}).Debug("Found resource in CloudFormation stack")
+
+	return resource.SerializedResource{
+		Id:   stackRes.PhysicalResourceId,
+		Type: ty,
+	}, true
+}
+
+func readSnapshot(path string) ([]stackResource, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := stackSnapshot{}
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		err = json.Unmarshal(content, &snapshot)
+	} else {
+		err = yaml.Unmarshal(content, &snapshot)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to decode CloudFormation resource listing %s", path)
+	}
+
+	return append(snapshot.StackResourceSummaries, snapshot.StackResources...), nil
+}
+
+// listStackResources lists resources of a stack and of its nested stacks
+func (r *CloudformationReader) listStackResources(stack string) ([]stackResource, error) {
+	if r.client == nil {
+		r.client = newClient()
+	}
+
+	results := make([]stackResource, 0)
+	nestedStacks := make([]string, 0)
+	err := r.client.ListStackResourcesPages(
+		&cloudformation.ListStackResourcesInput{StackName: aws.String(stack)},
+		func(output *cloudformation.ListStackResourcesOutput, lastPage bool) bool {
+			for _, summary := range output.StackResourceSummaries {
+				stackRes := stackResource{
+					LogicalResourceId:  aws.StringValue(summary.LogicalResourceId),
+					PhysicalResourceId: aws.StringValue(summary.PhysicalResourceId),
+					ResourceType:       aws.StringValue(summary.ResourceType),
+					ResourceStatus:     aws.StringValue(summary.ResourceStatus),
+				}
+				if stackRes.ResourceType == nestedStackResourceType && stackRes.PhysicalResourceId != "" {
+					nestedStacks = append(nestedStacks, stackRes.PhysicalResourceId)
+				}
+				results = append(results, stackRes)
+			}
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return nil, errors.Errorf("Error listing resources of CloudFormation stack '%s': %s", stack, err)
+	}
+
+	for _, nestedStack := range nestedStacks {
+		nestedResources, err := r.listStackResources(nestedStack)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, nestedResources...)
+	}
+
+	return results, nil
+}
+
+// newClient creates a CloudFormation client from the standard AWS configuration.
+// AWS_ENDPOINT_URL_CLOUDFORMATION or AWS_ENDPOINT_URL can be used to target another endpoint (e.g. a local stand-in)
+func newClient() *cloudformation.CloudFormation {
+	sess := session.Must(session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	}))
+
+	awsConfig := aws.NewConfig()
+	for _, env := range []string{"AWS_ENDPOINT_URL_CLOUDFORMATION", "AWS_ENDPOINT_URL"} {
+		if endpoint := os.Getenv(env); endpoint != "" {
+			awsConfig.WithEndpoint(endpoint)
+			break
+		}
+	}
+	return cloudformation.New(sess, awsConfig)
+}
@@ -0,0 +1,165 @@
+package cloudformation
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+type typeFilterFunc func(ty string) bool
+
+func (f typeFilterFunc) IsTypeIgnored(ty string) bool {
+	return f(ty)
+}
+
+func TestCloudformationReader_Resources_Snapshot(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		typeFilter filter.TypeFilter
+		want       []resource.Resource
+		wantErr    string
+	}{
+		{
+			name: "test list-stack-resources json output",
+			path: "testdata/list-stack-resources.json",
+			want: []resource.Resource{
+				resource.SerializedResource{Id: "my-stack-bucket-1hwyn3s9wx3yc", Type: "aws_s3_bucket"},
+				resource.SerializedResource{Id: "https://sqs.us-east-1.amazonaws.com/123456789012/my-stack-Queue-1U4FL6DWAEY3E", Type: "aws_sqs_queue"},
+				resource.SerializedResource{Id: "my-stack-Role-UZ1X8IJSOVDC", Type: "aws_iam_role"},
+			},
+		},
+		{
+			name: "test describe-stack-resources yaml output",
+			path: "testdata/describe-stack-resources.yaml",
+			want: []resource.Resource{
+				resource.SerializedResource{Id: "arn:aws:sns:us-east-1:123456789012:my-stack-Topic-JJ8SX4ORKWZV", Type: "aws_sns_topic"},
+				resource.SerializedResource{Id: "vpc-0a4bf1c6f15b42d6e", Type: "aws_vpc"},
+			},
+		},
+		{
+			name: "test ignored types are filtered out",
+			path: "testdata/list-stack-resources.json",
+			typeFilter: typeFilterFunc(func(ty string) bool {
+				return ty != "aws_iam_role"
+			}),
+			want: []resource.Resource{
+				resource.SerializedResource{Id: "my-stack-Role-UZ1X8IJSOVDC", Type: "aws_iam_role"},
+			},
+		},
+		{
+			name:    "test invalid listing",
+			path:    "testdata/invalid.json",
+			wantErr: "Unable to decode CloudFormation resource listing testdata/invalid.json: unexpected end of JSON input",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewReader(config.SupplierConfig{Key: CloudformationSupplier, Path: tt.path}, tt.typeFilter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := r.Resources()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCloudformationReader_Resources_API(t *testing.T) {
+	responses := map[string]string{
+		"my-stack": `
+			<member>
+				<LogicalResourceId>Bucket</LogicalResourceId>
+				<PhysicalResourceId>my-stack-bucket-1hwyn3s9wx3yc</PhysicalResourceId>
+				<ResourceStatus>CREATE_COMPLETE</ResourceStatus>
+				<ResourceType>AWS::S3::Bucket</ResourceType>
+			</member>
+			<member>
+				<LogicalResourceId>Network</LogicalResourceId>
+				<PhysicalResourceId>arn:aws:cloudformation:us-east-1:123456789012:stack/my-stack-Network-1E1J2F5R8R8VS/1c2fa620</PhysicalResourceId>
+				<ResourceStatus>CREATE_COMPLETE</ResourceStatus>
+				<ResourceType>AWS::CloudFormation::Stack</ResourceType>
+			</member>`,
+		"arn:aws:cloudformation:us-east-1:123456789012:stack/my-stack-Network-1E1J2F5R8R8VS/1c2fa620": `
+			<member>
+				<LogicalResourceId>Vpc</LogicalResourceId>
+				<PhysicalResourceId>vpc-0a4bf1c6f15b42d6e</PhysicalResourceId>
+				<ResourceStatus>CREATE_COMPLETE</ResourceStatus>
+				<ResourceType>AWS::EC2::VPC</ResourceType>
+			</member>`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		members, exists := responses[r.PostForm.Get("StackName")]
+		if r.PostForm.Get("Action") != "ListStackResources" || !exists {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>ValidationError</Code><Message>Stack does not exist</Message></Error></ErrorResponse>`)
+			return
+		}
+		fmt.Fprintf(w, `<ListStackResourcesResponse xmlns="http://cloudformation.amazonaws.com/doc/2010-05-15/">
+			<ListStackResourcesResult><StackResourceSummaries>%s</StackResourceSummaries></ListStackResourcesResult>
+		</ListStackResourcesResponse>`, members)
+	}))
+	defer server.Close()
+
+	env := map[string]string{
+		"AWS_ENDPOINT_URL_CLOUDFORMATION": server.URL,
+		"AWS_REGION":                      "us-east-1",
+		"AWS_ACCESS_KEY_ID":               "test",
+		"AWS_SECRET_ACCESS_KEY":           "test",
+	}
+	for key, value := range env {
+		os.Setenv(key, value)
+		defer os.Unsetenv(key)
+	}
+
+	tests := []struct {
+		name    string
+		stack   string
+		want    []resource.Resource
+		wantErr bool
+	}{
+		{
+			name:  "test resources of nested stacks are listed",
+			stack: "my-stack",
+			want: []resource.Resource{
+				resource.SerializedResource{Id: "my-stack-bucket-1hwyn3s9wx3yc", Type: "aws_s3_bucket"},
+				resource.SerializedResource{Id: "vpc-0a4bf1c6f15b42d6e", Type: "aws_vpc"},
+			},
+		},
+		{
+			name:    "test unknown stack",
+			stack:   "unknown-stack",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewReader(config.SupplierConfig{Key: CloudformationSupplier, Path: tt.stack}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := r.Resources()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -4,4 +4,11 @@ type SupplierConfig struct {
 	Key     string
 	Backend string
 	Path    string
+	// Workspaces contains terraform workspace name patterns to read states of, only used by the s3 backend
+	Workspaces []string
+	// WorkspaceKeyPrefix is the prefix under which non default workspace states are stored, env: by default
+	WorkspaceKeyPrefix string
+	// TFCloudEndpoint is the API endpoint of the Terraform Enterprise instance storing the state, e.g. from
+	// the hostname of a remote backend, only used by the tfcloud backend instead of the tfc-endpoint option
+	TFCloudEndpoint string
 }
@@ -68,3 +68,13 @@ func Deserializers() []deserializer.CTYDeserializer {
 		ghdeserializer.NewGithubBranchProtectionDeserializer(),
 	}
 }
+
+// SupportedResourceTypes returns every resource type that driftctl is able to deserialize
+func SupportedResourceTypes() []string {
+	deserializers := Deserializers()
+	types := make([]string, 0, len(deserializers))
+	for _, d := range deserializers {
+		types = append(types, d.HandledType().String())
+	}
+	return types
+}
@@ -0,0 +1,161 @@
+package pulumi
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+const PulumiSupplier = "pulumi"
+
+// resourceTypes maps Pulumi AWS type tokens to terraform resource types.
+// The Pulumi AWS provider is bridged from the terraform one, resource ids are the terraform ids.
+// Attachments and security group rules are not listed, driftctl rebuilds them from other resources.
+var resourceTypes = map[string]string{
+	"aws:cloudfront/distribution:Distribution":             "aws_cloudfront_distribution",
+	"aws:dynamodb/table:Table":                             "aws_dynamodb_table",
+	"aws:ebs/snapshot:Snapshot":                            "aws_ebs_snapshot",
+	"aws:ebs/volume:Volume":                                "aws_ebs_volume",
+	"aws:ec2/ami:Ami":                                      "aws_ami",
+	"aws:ec2/defaultRouteTable:DefaultRouteTable":          "aws_default_route_table",
+	"aws:ec2/defaultSecurityGroup:DefaultSecurityGroup":    "aws_default_security_group",
+	"aws:ec2/defaultSubnet:DefaultSubnet":                  "aws_default_subnet",
+	"aws:ec2/defaultVpc:DefaultVpc":                        "aws_default_vpc",
+	"aws:ec2/eip:Eip":                                      "aws_eip",
+	"aws:ec2/eipAssociation:EipAssociation":                "aws_eip_association",
+	"aws:ec2/instance:Instance":                            "aws_instance",
+	"aws:ec2/internetGateway:InternetGateway":              "aws_internet_gateway",
+	"aws:ec2/keyPair:KeyPair":                              "aws_key_pair",
+	"aws:ec2/natGateway:NatGateway":                        "aws_nat_gateway",
+	"aws:ec2/route:Route":                                  "aws_route",
+	"aws:ec2/routeTable:RouteTable":                        "aws_route_table",
+	"aws:ec2/routeTableAssociation:RouteTableAssociation":  "aws_route_table_association",
+	"aws:ec2/securityGroup:SecurityGroup":                  "aws_security_group",
+	"aws:ec2/subnet:Subnet":                                "aws_subnet",
+	"aws:ec2/vpc:Vpc":                                      "aws_vpc",
+	"aws:ecr/repository:Repository":                        "aws_ecr_repository",
+	"aws:iam/accessKey:AccessKey":                          "aws_iam_access_key",
+	"aws:iam/policy:Policy":                                "aws_iam_policy",
+	"aws:iam/role:Role":                                    "aws_iam_role",
+	"aws:iam/rolePolicy:RolePolicy":                        "aws_iam_role_policy",
+	"aws:iam/user:User":                                    "aws_iam_user",
+	"aws:iam/userPolicy:UserPolicy":                        "aws_iam_user_policy",
+	"aws:kms/alias:Alias":                                  "aws_kms_alias",
+	"aws:kms/key:Key":                                      "aws_kms_key",
+	"aws:lambda/eventSourceMapping:EventSourceMapping":     "aws_lambda_event_source_mapping",
+	"aws:lambda/function:Function":                         "aws_lambda_function",
+	"aws:rds/instance:Instance":                            "aws_db_instance",
+	"aws:rds/subnetGroup:SubnetGroup":                      "aws_db_subnet_group",
+	"aws:route53/healthCheck:HealthCheck":                  "aws_route53_health_check",
+	"aws:route53/record:Record":                            "aws_route53_record",
+	"aws:route53/zone:Zone":                                "aws_route53_zone",
+	"aws:s3/analyticsConfiguration:AnalyticsConfiguration": "aws_s3_bucket_analytics_configuration",
+	"aws:s3/bucket:Bucket":                                 "aws_s3_bucket",
+	"aws:s3/bucketMetric:BucketMetric":                     "aws_s3_bucket_metric",
+	"aws:s3/bucketNotification:BucketNotification":         "aws_s3_bucket_notification",
+	"aws:s3/bucketPolicy:BucketPolicy":                     "aws_s3_bucket_policy",
+	"aws:s3/inventory:Inventory":                           "aws_s3_bucket_inventory",
+	"aws:sns/topic:Topic":                                  "aws_sns_topic",
+	"aws:sns/topicPolicy:TopicPolicy":                      "aws_sns_topic_policy",
+	"aws:sns/topicSubscription:TopicSubscription":          "aws_sns_topic_subscription",
+	"aws:sqs/queue:Queue":                                  "aws_sqs_queue",
+	"aws:sqs/queuePolicy:QueuePolicy":                      "aws_sqs_queue_policy",
+}
+
+// stackResource is a resource of a Pulumi deployment, only fields needed to identify it are decoded
+type stackResource struct {
+	URN      string `json:"urn"`
+	Custom   bool   `json:"custom"`
+	Delete   bool   `json:"delete"`
+	External bool   `json:"external"`
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+}
+
+// stackExport is the output of pulumi stack export
+type stackExport struct {
+	Deployment struct {
+		Resources []stackResource `json:"resources"`
+	} `json:"deployment"`
+}
+
+// PulumiReader reads resources of a Pulumi stack from the output of pulumi stack export.
+// Resources only carry their identifier, they are considered managed without comparing attributes.
+type PulumiReader struct {
+	config     config.SupplierConfig
+	typeFilter filter.TypeFilter
+}
+
+func NewReader(config config.SupplierConfig, typeFilter filter.TypeFilter) (*PulumiReader, error) {
+	if config.Path == "" {
+		return nil, errors.New("Unable to read Pulumi stack, a stack export file must be provided")
+	}
+	return &PulumiReader{config: config, typeFilter: typeFilter}, nil
+}
+
+func (r *PulumiReader) Resources() ([]resource.Resource, error) {
+	file, err := os.Open(r.config.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	export := stackExport{}
+	if err := json.NewDecoder(file).Decode(&export); err != nil {
+		return nil, errors.Wrapf(err, "Unable to decode Pulumi stack export %s", r.config.Path)
+	}
+
+	results := make([]resource.Resource, 0, len(export.Deployment.Resources))
+	for _, stackRes := range export.Deployment.Resources {
+		res, ok := r.toResource(stackRes)
+		if !ok {
+			continue
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+func (r *PulumiReader) toResource(stackRes stackResource) (resource.Resource, bool) {
+	logger := logrus.WithFields(logrus.Fields{
+		"urn": stackRes.URN,
+	})
+
+	// Components only group other resources
+	if !stackRes.Custom {
+		return nil, false
+	}
+
+	ty, exists := resourceTypes[stackRes.Type]
+	if !exists {
+		logger.Debug("Ignored Pulumi resource of unsupported type")
+		return nil, false
+	}
+	if stackRes.External {
+		logger.Debug("Ignored Pulumi resource read from an existing resource")
+		return nil, false
+	}
+	if stackRes.ID == "" || stackRes.Delete {
+		logger.Debug("Ignored Pulumi resource that does not exist")
+		return nil, false
+	}
+	if r.typeFilter != nil && r.typeFilter.IsTypeIgnored(ty) {
+		return nil, false
+	}
+
+	logger.WithFields(logrus.Fields{
+		"id":   stackRes.ID,
+		"type": ty,
+	}).Debug("Found resource in Pulumi stack")
+
+	return resource.SerializedResource{
+		Id:   stackRes.ID,
+		Type: ty,
+	}, true
+}
@@ -0,0 +1,82 @@
+package pulumi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+type typeFilterFunc func(ty string) bool
+
+func (f typeFilterFunc) IsTypeIgnored(ty string) bool {
+	return f(ty)
+}
+
+func TestPulumiReader_Resources(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		typeFilter filter.TypeFilter
+		want       []resource.Resource
+		wantErr    string
+	}{
+		{
+			name: "test stack export",
+			path: "testdata/stack-export.json",
+			want: []resource.Resource{
+				resource.SerializedResource{Id: "assets-4f1c2a3", Type: "aws_s3_bucket"},
+				resource.SerializedResource{Id: "https://sqs.us-east-1.amazonaws.com/123456789012/jobs-9d2e1f0", Type: "aws_sqs_queue"},
+				resource.SerializedResource{Id: "worker-7c1d2e3", Type: "aws_iam_role"},
+			},
+		},
+		{
+			name: "test stack export with default resources",
+			path: "testdata/default-resources.json",
+			want: []resource.Resource{
+				resource.SerializedResource{Id: "vpc-default", Type: "aws_default_vpc"},
+				resource.SerializedResource{Id: "subnet-default", Type: "aws_default_subnet"},
+				resource.SerializedResource{Id: "rtb-default", Type: "aws_default_route_table"},
+				resource.SerializedResource{Id: "sg-default", Type: "aws_default_security_group"},
+			},
+		},
+		{
+			name: "test ignored types are filtered out",
+			path: "testdata/stack-export.json",
+			typeFilter: typeFilterFunc(func(ty string) bool {
+				return ty != "aws_s3_bucket"
+			}),
+			want: []resource.Resource{
+				resource.SerializedResource{Id: "assets-4f1c2a3", Type: "aws_s3_bucket"},
+			},
+		},
+		{
+			name:    "test invalid stack export",
+			path:    "testdata/invalid.json",
+			wantErr: "Unable to decode Pulumi stack export testdata/invalid.json: unexpected EOF",
+		},
+		{
+			name:    "test missing stack export",
+			path:    "testdata/missing.json",
+			wantErr: "open testdata/missing.json: no such file or directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewReader(config.SupplierConfig{Key: PulumiSupplier, Path: tt.path}, tt.typeFilter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := r.Resources()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,21 +2,35 @@ package supplier
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/cloudskiff/driftctl/pkg/alerter"
+	"github.com/cloudskiff/driftctl/pkg/filter"
 	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
 	"github.com/cloudskiff/driftctl/pkg/terraform"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	"github.com/cloudskiff/driftctl/pkg/iac/cloudformation"
 	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/pulumi"
 
 	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/terragrunt"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/tfdir"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/tfhcl"
 
 	"github.com/cloudskiff/driftctl/pkg/resource"
 )
 
 var supportedSuppliers = []string{
 	state.TerraformStateReaderSupplier,
+	tfdir.TerraformDirSupplier,
+	state.TerraformPlanReaderSupplier,
+	cloudformation.CloudformationSupplier,
+	pulumi.PulumiSupplier,
+	tfhcl.TerraformHCLSupplier,
+	terragrunt.TerragruntSupplier,
 }
 
 func IsSupplierSupported(supplierKey string) bool {
@@ -28,8 +42,9 @@ func IsSupplierSupported(supplierKey string) bool {
 	return false
 }
 
-func GetIACSupplier(configs []config.SupplierConfig, library *terraform.ProviderLibrary, backendOpts *backend.Options) (resource.Supplier, error) {
+func GetIACSupplier(configs []config.SupplierConfig, library *terraform.ProviderLibrary, backendOpts *backend.Options, typeFilter filter.TypeFilter, alerter alerter.AlerterInterface) (resource.Supplier, error) {
 	chainSupplier := resource.NewChainSupplier()
+	stateChecker := state.NewStateChecker(alerter, backendOpts)
 	for _, config := range configs {
 		if !IsSupplierSupported(config.Key) {
 			return nil, errors.Errorf("Unsupported supplier '%s'", config.Key)
@@ -39,12 +54,37 @@ func GetIACSupplier(configs []config.SupplierConfig, library *terraform.Provider
 		var err error
 		switch config.Key {
 		case state.TerraformStateReaderSupplier:
-			supplier, err = state.NewReader(config, library, backendOpts)
+			supplier, err = state.NewReader(config, library, backendOpts, typeFilter, alerter, stateChecker)
+		case tfdir.TerraformDirSupplier:
+			supplier, err = newTerraformDirReader(config, library, backendOpts, typeFilter, alerter, stateChecker)
+		case state.TerraformPlanReaderSupplier:
+			supplier, err = state.NewPlanReader(config, library, backendOpts, typeFilter)
+		case cloudformation.CloudformationSupplier:
+			supplier, err = cloudformation.NewReader(config, typeFilter)
+		case pulumi.PulumiSupplier:
+			supplier, err = pulumi.NewReader(config, typeFilter)
+		case tfhcl.TerraformHCLSupplier:
+			supplier, err = tfhcl.NewReader(config, typeFilter)
+		case terragrunt.TerragruntSupplier:
+			// Every unit of the repository is read as a distinct state source
+			var unitSuppliers []resource.Supplier
+			unitSuppliers, err = newTerragruntReaders(config, library, backendOpts, typeFilter, alerter, stateChecker)
+			if err != nil {
+				break
+			}
+			for _, unitSupplier := range unitSuppliers {
+				chainSupplier.AddSupplier(unitSupplier)
+			}
+			continue
 		default:
 			return nil, errors.Errorf("Unsupported supplier '%s'", config.Key)
 		}
 
 		if err != nil {
+			if continueOnError(backendOpts) {
+				skipSource(alerter, sourceName(config), err)
+				continue
+			}
 			return nil, err
 		}
 
@@ -54,11 +94,110 @@ func GetIACSupplier(configs []config.SupplierConfig, library *terraform.Provider
 			"path":     config.Path,
 		}).Debug("Found IAC supplier")
 
+		if continueOnError(backendOpts) {
+			supplier = &skippingSupplier{supplier, sourceName(config), alerter}
+		}
 		chainSupplier.AddSupplier(supplier)
 	}
 	return chainSupplier, nil
 }
 
+// skippingSupplier skips an IaC source that cannot be read, the scan goes on without its resources
+type skippingSupplier struct {
+	supplier resource.Supplier
+	source   string
+	alerter  alerter.AlerterInterface
+}
+
+func (s *skippingSupplier) Resources() ([]resource.Resource, error) {
+	resources, err := s.supplier.Resources()
+	if err != nil {
+		skipSource(s.alerter, s.source, err)
+		return []resource.Resource{}, nil
+	}
+	return resources, nil
+}
+
+func (s *skippingSupplier) IsFieldIgnored(res resource.Resource, path []string) bool {
+	fieldFilter, ok := s.supplier.(resource.FieldFilterSupplier)
+	return ok && fieldFilter.IsFieldIgnored(res, path)
+}
+
+func continueOnError(backendOpts *backend.Options) bool {
+	return backendOpts != nil && backendOpts.ContinueOnError
+}
+
+// skipSource reports an IaC source that cannot be read
+func skipSource(alerter alerter.AlerterInterface, source string, err error) {
+	logrus.WithFields(logrus.Fields{
+		"source": source,
+		"err":    err.Error(),
+	}).Warn("Skipping IaC source that cannot be read")
+	alerter.SendAlert(source, state.NewSkippedStateAlert(source, err))
+}
+
+// sourceName returns an IaC source as given to the from flag
+func sourceName(config config.SupplierConfig) string {
+	if config.Backend == "" || config.Backend == backend.BackendKeyFile {
+		return fmt.Sprintf("%s://%s", config.Key, config.Path)
+	}
+	return fmt.Sprintf("%s+%s://%s", config.Key, config.Backend, config.Path)
+}
+
+// newTerraformDirReader reads the state of a terraform root module, found from its backend configuration
+func newTerraformDirReader(config config.SupplierConfig, library *terraform.ProviderLibrary, backendOpts *backend.Options, typeFilter filter.TypeFilter, alerter alerter.AlerterInterface, stateChecker *state.StateChecker) (*state.TerraformStateReader, error) {
+	stateConfig, err := tfdir.Resolve(config.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"dir":     config.Path,
+		"backend": stateConfig.Backend,
+		"path":    stateConfig.Path,
+	}).Debug("Resolved terraform state from backend configuration")
+
+	return state.NewReader(*stateConfig, library, backendOpts, typeFilter, alerter, stateChecker)
+}
+
+// newTerragruntReaders reads the state of every unit of a terragrunt repository.
+// Units whose state cannot be resolved are skipped with an alert when continuing on state errors.
+func newTerragruntReaders(config config.SupplierConfig, library *terraform.ProviderLibrary, backendOpts *backend.Options, typeFilter filter.TypeFilter, alerter alerter.AlerterInterface, stateChecker *state.StateChecker) ([]resource.Supplier, error) {
+	units, unitErrors, err := terragrunt.Discover(config.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(unitErrors) > 0 {
+		if !continueOnError(backendOpts) {
+			messages := make([]string, 0, len(unitErrors))
+			for _, unitErr := range unitErrors {
+				messages = append(messages, unitErr.Error())
+			}
+			return nil, errors.Errorf("Unable to resolve %d of %d terragrunt units:\n%s", len(unitErrors), len(units)+len(unitErrors), strings.Join(messages, "\n"))
+		}
+		for _, unitErr := range unitErrors {
+			skipSource(alerter, fmt.Sprintf("%s://%s", terragrunt.TerragruntSupplier, unitErr.Dir), unitErr)
+		}
+	}
+
+	suppliers := make([]resource.Supplier, 0, len(units))
+	for _, unit := range units {
+		logrus.WithFields(logrus.Fields{
+			"unit":    unit.Dir,
+			"backend": unit.State.Backend,
+			"path":    unit.State.Path,
+		}).Debug("Found terragrunt unit")
+
+		reader, err := state.NewReader(unit.State, library, backendOpts, typeFilter, alerter, stateChecker)
+		if err != nil {
+			return nil, err
+		}
+		suppliers = append(suppliers, reader)
+	}
+	return suppliers, nil
+}
+
 func GetSupportedSuppliers() []string {
 	return supportedSuppliers
 }
@@ -67,10 +206,14 @@ func GetSupportedSchemes() []string {
 	schemes := []string{
 		"tfstate://",
 	}
-	for _, supplier := range supportedSuppliers {
-		for _, backend := range backend.GetSupportedBackends() {
-			schemes = append(schemes, fmt.Sprintf("%s+%s://", supplier, backend))
-		}
+	for _, backend := range backend.GetSupportedBackends() {
+		schemes = append(schemes, fmt.Sprintf("%s+%s://", state.TerraformStateReaderSupplier, backend))
 	}
+	schemes = append(schemes, fmt.Sprintf("%s://", tfdir.TerraformDirSupplier))
+	schemes = append(schemes, fmt.Sprintf("%s://", state.TerraformPlanReaderSupplier))
+	schemes = append(schemes, fmt.Sprintf("%s://", cloudformation.CloudformationSupplier))
+	schemes = append(schemes, fmt.Sprintf("%s://", pulumi.PulumiSupplier))
+	schemes = append(schemes, fmt.Sprintf("%s://", tfhcl.TerraformHCLSupplier))
+	schemes = append(schemes, fmt.Sprintf("%s://", terragrunt.TerragruntSupplier))
 	return schemes
 }
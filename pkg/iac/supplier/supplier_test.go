@@ -5,7 +5,11 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/alerter"
 	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state"
 	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
 	"github.com/cloudskiff/driftctl/pkg/terraform"
 )
@@ -53,6 +57,18 @@ func TestGetIACSupplier(t *testing.T) {
 			},
 			wantErr: fmt.Errorf("Unsupported supplier 'foobar'"),
 		},
+		{
+			name: "test tfdir with missing directory",
+			args: args{
+				config: []config.SupplierConfig{
+					{Key: "tfdir", Path: "/tmp/dummy-folder/that/does/not/exist"},
+				},
+				options: &backend.Options{
+					Headers: map[string]string{},
+				},
+			},
+			wantErr: fmt.Errorf("stat /tmp/dummy-folder/that/does/not/exist: no such file or directory"),
+		},
 		{
 			name: "test valid tfstate://terraform.tfstate",
 			args: args{
@@ -79,10 +95,61 @@ func TestGetIACSupplier(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "test valid tfstate and pulumi sources",
+			args: args{
+				config: []config.SupplierConfig{
+					{Key: "tfstate", Backend: "", Path: "terraform.tfstate"},
+					{Key: "pulumi", Backend: "", Path: "stack-export.json"},
+				},
+				options: &backend.Options{
+					Headers: map[string]string{},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "test terragrunt repository with unresolvable unit",
+			args: args{
+				config: []config.SupplierConfig{
+					{Key: "terragrunt", Backend: "", Path: "../terraform/terragrunt/testdata/repo"},
+				},
+				options: &backend.Options{
+					Headers: map[string]string{},
+				},
+			},
+			wantErr: fmt.Errorf("Unable to resolve 2 of 6 terragrunt units:\nUnable to resolve state of terragrunt unit ../terraform/terragrunt/testdata/repo/dev/invalid: Unable to parse terragrunt configuration: ../terraform/terragrunt/testdata/repo/dev/invalid/terragrunt.hcl:9,1-1: Invalid expression; Expected the start of an expression, but found an invalid expression token.\nUnable to resolve state of terragrunt unit ../terraform/terragrunt/testdata/repo/dev/unresolvable: Unable to evaluate remote_state config of ../terraform/terragrunt/testdata/repo/dev/unresolvable/terragrunt.hcl: ../terraform/terragrunt/testdata/repo/dev/unresolvable/terragrunt.hcl:8,26-44: Call to unknown function; There is no function named \"get_aws_account_id\"."),
+		},
+		{
+			name: "test terragrunt repository continuing on state errors",
+			args: args{
+				config: []config.SupplierConfig{
+					{Key: "terragrunt", Backend: "", Path: "../terraform/terragrunt/testdata/repo"},
+				},
+				options: &backend.Options{
+					Headers:         map[string]string{},
+					ContinueOnError: true,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "test valid tfstate and tfhcl sources",
+			args: args{
+				config: []config.SupplierConfig{
+					{Key: "tfstate", Backend: "", Path: "terraform.tfstate"},
+					{Key: "tfhcl", Backend: "", Path: "terraform"},
+				},
+				options: &backend.Options{
+					Headers: map[string]string{},
+				},
+			},
+			wantErr: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := GetIACSupplier(tt.args.config, terraform.NewProviderLibrary(), tt.args.options)
+			_, err := GetIACSupplier(tt.args.config, terraform.NewProviderLibrary(), tt.args.options, nil, alerter.NewAlerter())
 			if tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
 				t.Errorf("GetIACSupplier() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -98,9 +165,73 @@ func TestGetSupportedSchemes(t *testing.T) {
 		"tfstate+s3://",
 		"tfstate+http://",
 		"tfstate+https://",
+		"tfstate+gs://",
+		"tfstate+azurerm://",
+		"tfstate+tfcloud://",
+		"tfstate+consul://",
+		"tfstate+stdin://",
+		"tfstate+git://",
+		"tfdir://",
+		"tfplan://",
+		"cfn://",
+		"pulumi://",
+		"tfhcl://",
+		"terragrunt://",
 	}
 
 	if got := GetSupportedSchemes(); !reflect.DeepEqual(got, want) {
 		t.Errorf("GetSupportedSchemes() = %v, want %v", got, want)
 	}
 }
+
+func TestGetIACSupplier_ContinueOnStateError(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      []config.SupplierConfig
+		wantSkipped []string
+	}{
+		{
+			name: "test tfdir without directory is skipped",
+			config: []config.SupplierConfig{
+				{Key: "tfdir", Path: "/tmp/dummy-folder/that/does/not/exist"},
+			},
+			wantSkipped: []string{"tfdir:///tmp/dummy-folder/that/does/not/exist"},
+		},
+		{
+			name: "test unreadable sources are skipped",
+			config: []config.SupplierConfig{
+				{Key: "tfplan", Path: "missing.tfplan.json"},
+				{Key: "cfn", Path: "missing.template.json"},
+				{Key: "pulumi", Path: "missing.json"},
+				{Key: "tfhcl", Path: "/tmp/dummy-folder/that/does/not/exist"},
+			},
+			wantSkipped: []string{
+				"tfplan://missing.tfplan.json",
+				"cfn://missing.template.json",
+				"pulumi://missing.json",
+				"tfhcl:///tmp/dummy-folder/that/does/not/exist",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alerter := alerter.NewAlerter()
+			supplier, err := GetIACSupplier(tt.config, terraform.NewProviderLibrary(), &backend.Options{ContinueOnError: true}, nil, alerter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resources, err := supplier.Resources()
+			assert.Nil(t, err)
+			assert.Empty(t, resources)
+
+			skipped := make([]string, 0)
+			for source, alerts := range alerter.Retrieve() {
+				for _, alert := range alerts {
+					assert.IsType(t, &state.SkippedStateAlert{}, alert)
+				}
+				skipped = append(skipped, source)
+			}
+			assert.ElementsMatch(t, tt.wantSkipped, skipped)
+		})
+	}
+}
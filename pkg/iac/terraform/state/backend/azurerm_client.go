@@ -0,0 +1,211 @@
+package backend
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const azureStorageVersion = "2019-12-12"
+
+type AzureBlob struct {
+	Name string
+	Size int64
+}
+
+type azureListBlobsResult struct {
+	Blobs []struct {
+		Name       string `xml:"Name"`
+		Properties struct {
+			ContentLength int64 `xml:"Content-Length"`
+		} `xml:"Properties"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+type azureStorageError struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// AzureBlobClient queries the blob service REST API of a storage account
+type AzureBlobClient struct {
+	account  string
+	endpoint string
+	key      []byte
+	sasToken url.Values
+	client   *http.Client
+}
+
+// NewAzureBlobClient creates a client authenticated with a SAS token (AZURE_STORAGE_SAS_TOKEN)
+// or a shared key (AZURE_STORAGE_KEY).
+// AZURE_STORAGE_ENDPOINT can be used to target another blob endpoint (e.g. http://127.0.0.1:10000/devstoreaccount1 for Azurite)
+func NewAzureBlobClient(account string) (*AzureBlobClient, error) {
+	client := AzureBlobClient{
+		account:  account,
+		endpoint: fmt.Sprintf("https://%s.blob.core.windows.net", account),
+		client:   &http.Client{},
+	}
+
+	if endpoint := os.Getenv("AZURE_STORAGE_ENDPOINT"); endpoint != "" {
+		client.endpoint = strings.TrimSuffix(endpoint, "/")
+	}
+
+	if sasToken := os.Getenv("AZURE_STORAGE_SAS_TOKEN"); sasToken != "" {
+		values, err := url.ParseQuery(strings.TrimPrefix(sasToken, "?"))
+		if err != nil {
+			return nil, errors.Wrap(err, "Unable to parse Azure SAS token")
+		}
+		client.sasToken = values
+		return &client, nil
+	}
+
+	if key := os.Getenv("AZURE_STORAGE_KEY"); key != "" {
+		decodedKey, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, errors.Wrap(err, "Unable to decode Azure storage key")
+		}
+		client.key = decodedKey
+		return &client, nil
+	}
+
+	return nil, errors.New("Unable to authenticate to Azure storage, AZURE_STORAGE_SAS_TOKEN or AZURE_STORAGE_KEY must be set")
+}
+
+func (c *AzureBlobClient) GetBlob(container, name string) (io.ReadCloser, error) {
+	response, err := c.get(fmt.Sprintf("/%s/%s", container, name), url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	return response.Body, nil
+}
+
+func (c *AzureBlobClient) ListBlobs(container, prefix string) ([]AzureBlob, error) {
+	blobs := make([]AzureBlob, 0)
+	marker := ""
+	for {
+		query := url.Values{
+			"restype": {"container"},
+			"comp":    {"list"},
+			"prefix":  {prefix},
+		}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+
+		response, err := c.get(fmt.Sprintf("/%s", container), query)
+		if err != nil {
+			return nil, err
+		}
+
+		result := azureListBlobsResult{}
+		err = xml.NewDecoder(response.Body).Decode(&result)
+		response.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "Unable to decode Azure blobs list")
+		}
+
+		for _, blob := range result.Blobs {
+			blobs = append(blobs, AzureBlob{
+				Name: blob.Name,
+				Size: blob.Properties.ContentLength,
+			})
+		}
+
+		if result.NextMarker == "" {
+			return blobs, nil
+		}
+		marker = result.NextMarker
+	}
+}
+
+func (c *AzureBlobClient) get(path string, query url.Values) (*http.Response, error) {
+	u, err := url.Parse(c.endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to parse Azure storage endpoint")
+	}
+	u.Path += path
+
+	for key, values := range c.sasToken {
+		query[key] = values
+	}
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureStorageVersion)
+	if c.key != nil {
+		req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", c.account, c.sign(req)))
+	}
+
+	response, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		body, _ := ioutil.ReadAll(response.Body)
+		storageErr := azureStorageError{}
+		if err := xml.Unmarshal(body, &storageErr); err == nil && storageErr.Code != "" {
+			return nil, errors.Errorf("%s (%d)", storageErr.Code, response.StatusCode)
+		}
+		return nil, errors.Errorf("%s", response.Status)
+	}
+
+	return response, nil
+}
+
+// sign computes the shared key signature of a GET request
+// https://docs.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key
+func (c *AzureBlobClient) sign(req *http.Request) string {
+	headerNames := make([]string, 0)
+	for name := range req.Header {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, "x-ms-") {
+			headerNames = append(headerNames, name)
+		}
+	}
+	sort.Strings(headerNames)
+
+	var canonicalizedHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalizedHeaders.WriteString(fmt.Sprintf("%s:%s\n", name, req.Header.Get(name)))
+	}
+
+	var canonicalizedResource strings.Builder
+	canonicalizedResource.WriteString(fmt.Sprintf("/%s%s", c.account, req.URL.EscapedPath()))
+	query := req.URL.Query()
+	queryKeys := make([]string, 0, len(query))
+	for key := range query {
+		queryKeys = append(queryKeys, key)
+	}
+	sort.Strings(queryKeys)
+	for _, key := range queryKeys {
+		values := query[key]
+		sort.Strings(values)
+		canonicalizedResource.WriteString(fmt.Sprintf("\n%s:%s", strings.ToLower(key), strings.Join(values, ",")))
+	}
+
+	// Verb followed by standard headers, which are all empty for our GET requests
+	stringToSign := req.Method + strings.Repeat("\n", 12) + canonicalizedHeaders.String() + canonicalizedResource.String()
+
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
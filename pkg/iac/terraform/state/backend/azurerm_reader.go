@@ -0,0 +1,58 @@
+package backend
+
+import (
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const BackendKeyAzureRM = "azurerm"
+
+type AzureRMBackend struct {
+	container string
+	key       string
+	reader    io.ReadCloser
+	client    *AzureBlobClient
+}
+
+func NewAzureRMReader(path string) (*AzureRMBackend, error) {
+	blobPath := strings.Split(path, "/")
+	if len(blobPath) < 3 {
+		return nil, errors.Errorf("Unable to parse azurerm path: %s. Must be ACCOUNT/CONTAINER/PATH/TO/BLOB", path)
+	}
+
+	client, err := NewAzureBlobClient(blobPath[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzureRMBackend{
+		container: blobPath[1],
+		key:       strings.Join(blobPath[2:], "/"),
+		client:    client,
+	}, nil
+}
+
+func (s *AzureRMBackend) Read(p []byte) (n int, err error) {
+	if s.reader == nil {
+		reader, err := s.client.GetBlob(s.container, s.key)
+		if err != nil {
+			return 0, errors.Errorf(
+				"Error reading state '%s' from azurerm container '%s': %s",
+				s.key,
+				s.container,
+				err,
+			)
+		}
+		s.reader = reader
+	}
+	return s.reader.Read(p)
+}
+
+func (s *AzureRMBackend) Close() error {
+	if s.reader != nil {
+		return s.reader.Close()
+	}
+	return errors.New("Unable to close reader as nothing was opened")
+}
@@ -0,0 +1,131 @@
+package backend
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAzureRMReaderInvalid(t *testing.T) {
+	_, err := NewAzureRMReader("account/container")
+	assert.EqualError(t, err, "Unable to parse azurerm path: account/container. Must be ACCOUNT/CONTAINER/PATH/TO/BLOB")
+
+	os.Unsetenv("AZURE_STORAGE_SAS_TOKEN")
+	os.Unsetenv("AZURE_STORAGE_KEY")
+	_, err = NewAzureRMReader("account/container/state.tfstate")
+	assert.EqualError(t, err, "Unable to authenticate to Azure storage, AZURE_STORAGE_SAS_TOKEN or AZURE_STORAGE_KEY must be set")
+}
+
+func TestAzureRMBackend_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		path    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "read state with SAS token",
+			env: map[string]string{
+				"AZURE_STORAGE_SAS_TOKEN": "?sv=2019-12-12&sig=signature",
+			},
+			path: "devstoreaccount1/states/a/nested/state.tfstate",
+			want: "{}",
+		},
+		{
+			name: "read state with shared key",
+			env: map[string]string{
+				"AZURE_STORAGE_KEY": base64.StdEncoding.EncodeToString([]byte("key")),
+			},
+			path: "devstoreaccount1/states/a/nested/state.tfstate",
+			want: "{}",
+		},
+		{
+			name: "state not found",
+			env: map[string]string{
+				"AZURE_STORAGE_SAS_TOKEN": "sv=2019-12-12&sig=signature",
+			},
+			path:    "devstoreaccount1/states/not-found.tfstate",
+			wantErr: "Error reading state 'not-found.tfstate' from azurerm container 'states': BlobNotFound (404)",
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorized := r.URL.Query().Get("sig") == "signature" ||
+			strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey devstoreaccount1:")
+		if !authorized {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/devstoreaccount1/states/a/nested/state.tfstate" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><Error><Code>BlobNotFound</Code><Message>The specified blob does not exist.</Message></Error>`)
+			return
+		}
+		fmt.Fprint(w, "{}")
+	}))
+	defer server.Close()
+
+	os.Setenv("AZURE_STORAGE_ENDPOINT", fmt.Sprintf("%s/devstoreaccount1", server.URL))
+	defer os.Unsetenv("AZURE_STORAGE_ENDPOINT")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				os.Setenv(key, value)
+				defer os.Unsetenv(key)
+			}
+			reader, err := NewAzureRMReader(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ioutil.ReadAll(reader)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, string(got))
+			assert.Nil(t, reader.Close())
+		})
+	}
+}
+
+func TestAzureBlobClient_sign(t *testing.T) {
+	// Azurite well-known development account, expected signatures are computed by the Azure storage SDK
+	key, _ := base64.StdEncoding.DecodeString("Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw==")
+	client := AzureBlobClient{account: "devstoreaccount1", key: key}
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{
+			name: "list blobs",
+			url:  "https://devstoreaccount1.blob.core.windows.net/states?restype=container&comp=list&prefix=env%2F",
+			want: "23AUBjKTxr9jk3gOXTlCPkks5v0pZG2BNZmhxoBvFdw=",
+		},
+		{
+			name: "get blob",
+			url:  "https://devstoreaccount1.blob.core.windows.net/states/env/prod.tfstate",
+			want: "x3NH/UB04hOx/GpbB5RcGg+sKwhJpDmC3ia9PHBS+i0=",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			req.Header.Set("x-ms-date", "Fri, 26 Feb 2021 10:00:00 GMT")
+			req.Header.Set("x-ms-version", "2019-12-12")
+
+			assert.Equal(t, tt.want, client.sign(req))
+		})
+	}
+}
@@ -13,12 +13,34 @@ var supportedBackends = []string{
 	BackendKeyS3,
 	BackendKeyHTTP,
 	BackendKeyHTTPS,
+	BackendKeyGS,
+	BackendKeyAzureRM,
+	BackendKeyTFCloud,
+	BackendKeyConsul,
+	BackendKeyStdin,
+	BackendKeyGit,
 }
 
 type Backend io.ReadCloser
 
+// DefaultParallelism is the number of enumerated states read at the same time
+const DefaultParallelism = 10
+
 type Options struct {
-	Headers map[string]string
+	Headers         map[string]string
+	TFCloudToken    string
+	TFCloudEndpoint string
+	// GSEndpoint is the Google Cloud Storage API endpoint, Google APIs are used when not set
+	GSEndpoint       string
+	AgeIdentityFiles []string
+	// Parallelism is the number of enumerated states read at the same time, DefaultParallelism when not set
+	Parallelism int
+	// ContinueOnError skips sources and enumerated states that cannot be read instead of failing
+	ContinueOnError bool
+	// DataSources reads resources referenced by data sources, they are reported as managed outside of the scanned IaC
+	DataSources bool
+	// LockTable is the DynamoDB table where the s3 backend stores state locks, locks are not checked when empty
+	LockTable string
 }
 
 func IsSupported(backend string) bool {
@@ -47,6 +69,18 @@ func GetBackend(config config.SupplierConfig, opts *Options) (Backend, error) {
 		fallthrough
 	case BackendKeyHTTPS:
 		return NewHTTPReader(fmt.Sprintf("%s://%s", config.Backend, config.Path), opts)
+	case BackendKeyGS:
+		return NewGSReader(config.Path, opts)
+	case BackendKeyAzureRM:
+		return NewAzureRMReader(config.Path)
+	case BackendKeyTFCloud:
+		return NewTFCloudReader(config.Path, TFCloudOptions(config, opts))
+	case BackendKeyConsul:
+		return NewConsulReader(config.Path)
+	case BackendKeyStdin:
+		return NewStdinReader()
+	case BackendKeyGit:
+		return NewGitReader(config.Path)
 	default:
 		return nil, errors.Errorf("Unsupported backend '%s'", backend)
 	}
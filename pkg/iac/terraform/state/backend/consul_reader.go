@@ -0,0 +1,187 @@
+package backend
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const BackendKeyConsul = "consul"
+
+// ConsulClient queries the Consul KV HTTP API.
+// It is configured with the standard CONSUL_HTTP_ADDR, CONSUL_HTTP_TOKEN and CONSUL_HTTP_SSL env vars
+type ConsulClient struct {
+	address string
+	token   string
+	client  *http.Client
+}
+
+func NewConsulClient() *ConsulClient {
+	address := os.Getenv("CONSUL_HTTP_ADDR")
+	if address == "" {
+		address = "127.0.0.1:8500"
+	}
+	if !strings.Contains(address, "://") {
+		scheme := "http"
+		if ssl := strings.ToLower(os.Getenv("CONSUL_HTTP_SSL")); ssl == "true" || ssl == "1" {
+			scheme = "https"
+		}
+		address = fmt.Sprintf("%s://%s", scheme, address)
+	}
+
+	return &ConsulClient{
+		address: strings.TrimSuffix(address, "/"),
+		token:   os.Getenv("CONSUL_HTTP_TOKEN"),
+		client:  &http.Client{},
+	}
+}
+
+// Get returns the raw value of a key, or nil when the key does not exist
+func (c *ConsulClient) Get(key string) ([]byte, error) {
+	body, err := c.get(key, url.Values{"raw": {""}})
+	if err != nil || body == nil {
+		return nil, err
+	}
+	defer body.Close()
+	return ioutil.ReadAll(body)
+}
+
+// Keys returns every key starting with the given prefix
+func (c *ConsulClient) Keys(prefix string) ([]string, error) {
+	body, err := c.get(prefix, url.Values{"keys": {""}})
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0)
+	if body == nil {
+		return keys, nil
+	}
+	defer body.Close()
+	if err := json.NewDecoder(body).Decode(&keys); err != nil {
+		return nil, errors.Wrap(err, "Unable to decode consul keys")
+	}
+	return keys, nil
+}
+
+func (c *ConsulClient) get(key string, query url.Values) (io.ReadCloser, error) {
+	u := fmt.Sprintf("%s/v1/kv/%s?%s", c.address, strings.TrimPrefix(key, "/"), query.Encode())
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("X-Consul-Token", c.token)
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		return nil, nil
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 400 {
+		res.Body.Close()
+		return nil, errors.Errorf("error requesting consul KV: status code: %d", res.StatusCode)
+	}
+
+	return res.Body, nil
+}
+
+type ConsulBackend struct {
+	path   string
+	reader io.ReadCloser
+	client *ConsulClient
+}
+
+func NewConsulReader(path string) (*ConsulBackend, error) {
+	if strings.Trim(path, "/") == "" {
+		return nil, errors.Errorf("Unable to parse consul path: %s. Must be PATH/TO/KEY", path)
+	}
+	return &ConsulBackend{
+		path:   path,
+		client: NewConsulClient(),
+	}, nil
+}
+
+func (c *ConsulBackend) Read(p []byte) (n int, err error) {
+	if c.reader == nil {
+		payload, err := c.readState()
+		if err != nil {
+			return 0, errors.Errorf("Error reading state '%s' from consul: %s", c.path, err)
+		}
+		c.reader = ioutil.NopCloser(bytes.NewReader(payload))
+	}
+	return c.reader.Read(p)
+}
+
+func (c *ConsulBackend) Close() error {
+	if c.reader != nil {
+		return c.reader.Close()
+	}
+	return errors.New("Unable to close reader as nothing was opened")
+}
+
+// readState reads a state the way terraform consul backend writes it.
+// Large states are split in chunks, the state key then contains the list of chunks
+// along with the MD5 sum of the state. Payload may be gzip compressed.
+func (c *ConsulBackend) readState() ([]byte, error) {
+	payload, err := c.client.Get(c.path)
+	if err != nil {
+		return nil, err
+	}
+	if payload == nil {
+		return nil, errors.New("key not found")
+	}
+
+	hash := ""
+	chunkedPayload := struct {
+		Hash   string   `json:"current-hash"`
+		Chunks []string `json:"chunks"`
+	}{}
+	if err := json.Unmarshal(payload, &chunkedPayload); err == nil && chunkedPayload.Hash != "" {
+		hash = chunkedPayload.Hash
+		payload = []byte{}
+		for _, chunkPath := range chunkedPayload.Chunks {
+			chunk, err := c.client.Get(chunkPath)
+			if err != nil {
+				return nil, err
+			}
+			if chunk == nil {
+				return nil, errors.Errorf("chunk '%s' not found", chunkPath)
+			}
+			payload = append(payload, chunk...)
+		}
+	}
+
+	// If the payload starts with 0x1f, it's gzip, not json
+	if len(payload) >= 1 && payload[0] == '\x1f' {
+		reader, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		payload, err = ioutil.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if hash != "" && fmt.Sprintf("%x", md5.Sum(payload)) != hash {
+		return nil, errors.New("state does not match the expected hash")
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,162 @@
+package backend
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/md5"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func gzipPayload(t *testing.T, payload string) []byte {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(payload)); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestConsulBackend_Read(t *testing.T) {
+	state := `{"version": 4}`
+	hash := fmt.Sprintf("%x", md5.Sum([]byte(state)))
+	gzipState := gzipPayload(t, state)
+
+	kv := map[string][]byte{
+		"states/plain": []byte(state),
+		"states/gzip":  gzipState,
+		"states/chunked": []byte(fmt.Sprintf(
+			`{"current-hash": "%s", "chunks": ["states/chunked/tfstate.%s/0", "states/chunked/tfstate.%s/1"]}`,
+			hash, hash, hash,
+		)),
+		fmt.Sprintf("states/chunked/tfstate.%s/0", hash): []byte(state[:5]),
+		fmt.Sprintf("states/chunked/tfstate.%s/1", hash): []byte(state[5:]),
+		"states/chunked-gzip": []byte(fmt.Sprintf(
+			`{"current-hash": "%s", "chunks": ["states/chunked-gzip/tfstate.%s/0", "states/chunked-gzip/tfstate.%s/1"]}`,
+			hash, hash, hash,
+		)),
+		fmt.Sprintf("states/chunked-gzip/tfstate.%s/0", hash): gzipState[:10],
+		fmt.Sprintf("states/chunked-gzip/tfstate.%s/1", hash): gzipState[10:],
+		"states/bad-hash": []byte(`{"current-hash": "abcdef", "chunks": ["states/plain"]}`),
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "read plain state",
+			path: "states/plain",
+			want: state,
+		},
+		{
+			name: "read gzip state",
+			path: "states/gzip",
+			want: state,
+		},
+		{
+			name: "read chunked state",
+			path: "states/chunked",
+			want: state,
+		},
+		{
+			name: "read chunked gzip state",
+			path: "states/chunked-gzip",
+			want: state,
+		},
+		{
+			name:    "state does not match hash",
+			path:    "states/bad-hash",
+			wantErr: "Error reading state 'states/bad-hash' from consul: state does not match the expected hash",
+		},
+		{
+			name:    "state not found",
+			path:    "states/not-found",
+			wantErr: "Error reading state 'states/not-found' from consul: key not found",
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Consul-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		value, exists := kv[r.URL.Path[len("/v1/kv/"):]]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(value)
+	}))
+	defer server.Close()
+
+	os.Setenv("CONSUL_HTTP_ADDR", server.URL)
+	os.Setenv("CONSUL_HTTP_TOKEN", "token")
+	defer os.Unsetenv("CONSUL_HTTP_ADDR")
+	defer os.Unsetenv("CONSUL_HTTP_TOKEN")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewConsulReader(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ioutil.ReadAll(reader)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, string(got))
+			assert.Nil(t, reader.Close())
+		})
+	}
+}
+
+func TestNewConsulClient(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{
+			name: "default address",
+			want: "http://127.0.0.1:8500",
+		},
+		{
+			name: "address without scheme",
+			env:  map[string]string{"CONSUL_HTTP_ADDR": "consul.local:8500"},
+			want: "http://consul.local:8500",
+		},
+		{
+			name: "address with ssl",
+			env:  map[string]string{"CONSUL_HTTP_ADDR": "consul.local:8501", "CONSUL_HTTP_SSL": "true"},
+			want: "https://consul.local:8501",
+		},
+		{
+			name: "address with scheme",
+			env:  map[string]string{"CONSUL_HTTP_ADDR": "https://consul.local/"},
+			want: "https://consul.local",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				os.Setenv(key, value)
+				defer os.Unsetenv(key)
+			}
+			assert.Equal(t, tt.want, NewConsulClient().address)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package backend
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+const ageIntro = "age-encryption.org/v1"
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// NewDecodingReader detects compressed or encrypted states and returns a reader of the plain state.
+// Layers are removed one after the other, so an encrypted gzip state is also supported.
+func NewDecodingReader(reader io.Reader, opts *Options) (io.Reader, error) {
+	buffered := bufio.NewReader(reader)
+	magic, _ := buffered.Peek(len(armor.Header))
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gzipReader, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, errors.Wrap(err, "Unable to read gzip compressed state")
+		}
+		return NewDecodingReader(gzipReader, opts)
+	case bytes.HasPrefix(magic, zstdMagic):
+		// A single block is decoded at a time, so the decoder does not start any goroutine to release
+		zstdReader, err := zstd.NewReader(buffered, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, errors.Wrap(err, "Unable to read zstd compressed state")
+		}
+		return NewDecodingReader(zstdReader, opts)
+	case bytes.HasPrefix(magic, []byte(ageIntro)), bytes.HasPrefix(magic, []byte(armor.Header)):
+		identities, err := readAgeIdentityFiles(opts)
+		if err != nil {
+			return nil, err
+		}
+		var encrypted io.Reader = buffered
+		if bytes.HasPrefix(magic, []byte(armor.Header)) {
+			encrypted = armor.NewReader(buffered)
+		}
+		decrypted, err := age.Decrypt(encrypted, identities...)
+		if err != nil {
+			return nil, errors.Wrap(err, "Unable to decrypt age encrypted state")
+		}
+		return NewDecodingReader(decrypted, opts)
+	}
+
+	return buffered, nil
+}
+
+func readAgeIdentityFiles(opts *Options) ([]age.Identity, error) {
+	if opts == nil || len(opts.AgeIdentityFiles) == 0 {
+		return nil, errors.New("State is age encrypted, an identity file must be provided with --tfstate-age-identity")
+	}
+
+	identities := make([]age.Identity, 0)
+	for _, path := range opts.AgeIdentityFiles {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		fileIdentities, err := age.ParseIdentities(file)
+		file.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Unable to read age identity file %s", path)
+		}
+		identities = append(identities, fileIdentities...)
+	}
+	return identities, nil
+}
@@ -0,0 +1,95 @@
+package backend
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDecodingReader(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		opts     *Options
+		expected string
+		err      string
+	}{
+		{
+			name:     "plain state",
+			path:     "testdata/encoded/state.tfstate",
+			opts:     &Options{},
+			expected: "testdata/encoded/state.tfstate",
+		},
+		{
+			name:     "gzip compressed state",
+			path:     "testdata/encoded/state.tfstate.gz",
+			opts:     &Options{},
+			expected: "testdata/encoded/state.tfstate",
+		},
+		{
+			name:     "zstd compressed state",
+			path:     "testdata/encoded/state.tfstate.zst",
+			opts:     &Options{},
+			expected: "testdata/encoded/state.tfstate",
+		},
+		{
+			name:     "age encrypted state",
+			path:     "testdata/encoded/state.tfstate.age",
+			opts:     &Options{AgeIdentityFiles: []string{"testdata/encoded/identity.txt"}},
+			expected: "testdata/encoded/state.tfstate",
+		},
+		{
+			name:     "armored age encrypted state",
+			path:     "testdata/encoded/state.tfstate.age.asc",
+			opts:     &Options{AgeIdentityFiles: []string{"testdata/encoded/identity.txt"}},
+			expected: "testdata/encoded/state.tfstate",
+		},
+		{
+			name:     "age encrypted gzip compressed state",
+			path:     "testdata/encoded/state.tfstate.gz.age",
+			opts:     &Options{AgeIdentityFiles: []string{"testdata/encoded/identity.txt"}},
+			expected: "testdata/encoded/state.tfstate",
+		},
+		{
+			name: "age encrypted state without identity",
+			path: "testdata/encoded/state.tfstate.age",
+			opts: nil,
+			err:  "State is age encrypted, an identity file must be provided with --tfstate-age-identity",
+		},
+		{
+			name: "age encrypted state for another recipient",
+			path: "testdata/encoded/other-recipient.tfstate.age",
+			opts: &Options{AgeIdentityFiles: []string{"testdata/encoded/identity.txt"}},
+			err:  "Unable to decrypt age encrypted state: no identity matched any of the recipients",
+		},
+		{
+			name: "invalid identity file",
+			path: "testdata/encoded/state.tfstate.age",
+			opts: &Options{AgeIdentityFiles: []string{"testdata/encoded/state.tfstate"}},
+			err:  "Unable to read age identity file testdata/encoded/state.tfstate: error at line 1: malformed secret key: separator '1' at invalid position: pos=-1, len=1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := os.Open(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+
+			reader, err := NewDecodingReader(file, tt.opts)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.Nil(t, err)
+
+			got, err := ioutil.ReadAll(reader)
+			assert.Nil(t, err)
+			expected, _ := ioutil.ReadFile(tt.expected)
+			assert.Equal(t, expected, got)
+		})
+	}
+}
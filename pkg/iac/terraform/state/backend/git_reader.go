@@ -0,0 +1,122 @@
+package backend
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const BackendKeyGit = "git"
+
+// GitBackend reads a state committed at a given revision of a local git repository
+type GitBackend struct {
+	repository string
+	revision   string
+	path       string
+	reader     io.ReadCloser
+}
+
+// NewGitReader parses a REPO_PATH@REF:path/to/state path.
+// The state path is relative to the root of the repository
+func NewGitReader(path string) (*GitBackend, error) {
+	repoIndex := gitRepositoryIndex(path)
+	if repoIndex <= 0 {
+		return nil, errors.Errorf("Unable to parse git path: %s. Must be REPO_PATH@REF:PATH/TO/STATE", path)
+	}
+	revision, statePath := splitGitRevision(path[repoIndex+1:])
+	if revision == "" || statePath == "" {
+		return nil, errors.Errorf("Unable to parse git path: %s. Must be REPO_PATH@REF:PATH/TO/STATE", path)
+	}
+	// The revision is given to git as an argument, it must not be taken as an option
+	if strings.HasPrefix(revision, "-") {
+		return nil, errors.Errorf("Invalid git revision: %s", revision)
+	}
+
+	return &GitBackend{
+		repository: path[:repoIndex],
+		revision:   revision,
+		path:       statePath,
+	}, nil
+}
+
+// gitRepositoryIndex returns the index of the @ following the repository path, -1 if there is none.
+// Revisions may contain @, e.g. HEAD@{1}, so the first @ is used unless the path before a later one
+// is an existing directory, repository paths may contain @ too
+func gitRepositoryIndex(path string) int {
+	first := strings.Index(path, "@")
+	for i := first; i != -1; {
+		if info, err := os.Stat(path[:i]); err == nil && info.IsDir() {
+			return i
+		}
+		next := strings.Index(path[i+1:], "@")
+		if next == -1 {
+			break
+		}
+		i += next + 1
+	}
+	return first
+}
+
+// splitGitRevision splits REF:PATH on the first colon outside of braces,
+// reflog selectors may contain one, e.g. main@{2021-01-01 10:00}
+func splitGitRevision(revisionPath string) (string, string) {
+	depth := 0
+	for i, c := range revisionPath {
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			if depth > 0 {
+				depth--
+			}
+		case ':':
+			if depth == 0 {
+				return revisionPath[:i], revisionPath[i+1:]
+			}
+		}
+	}
+	return revisionPath, ""
+}
+
+func (g *GitBackend) Read(p []byte) (n int, err error) {
+	if g.reader == nil {
+		payload, err := g.readBlob()
+		if err != nil {
+			return 0, errors.Errorf(
+				"Error reading state '%s' at revision '%s' of git repository '%s': %s",
+				g.path,
+				g.revision,
+				g.repository,
+				err,
+			)
+		}
+		g.reader = ioutil.NopCloser(bytes.NewReader(payload))
+	}
+	return g.reader.Read(p)
+}
+
+func (g *GitBackend) Close() error {
+	if g.reader != nil {
+		return g.reader.Close()
+	}
+	return errors.New("Unable to close reader as nothing was opened")
+}
+
+func (g *GitBackend) readBlob() ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", "-C", g.repository, "cat-file", "blob", g.revision+":"+g.path)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, errors.New(message)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,166 @@
+package backend
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func gitRepository(t *testing.T, states ...string) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir, err := ioutil.TempDir("", "driftctl-git-backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=driftctl", "-c", "user.email=driftctl@example.com"}, args...)...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, output)
+		}
+	}
+
+	git("init", "-q")
+	if err := os.MkdirAll(filepath.Join(dir, "states"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for i, state := range states {
+		if err := ioutil.WriteFile(filepath.Join(dir, "states", "prod.tfstate"), []byte(state), 0644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", "-A")
+		git("commit", "-q", "-m", "state")
+		git("tag", fmt.Sprintf("v%d", i+1))
+	}
+	return dir
+}
+
+func TestGitBackend_Read(t *testing.T) {
+	repository := gitRepository(t, `{"serial": 1}`, `{"serial": 2}`)
+	defer os.RemoveAll(repository)
+
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "read state at tag",
+			path: repository + "@v1:states/prod.tfstate",
+			want: `{"serial": 1}`,
+		},
+		{
+			name: "read state at relative revision",
+			path: repository + "@HEAD~1:states/prod.tfstate",
+			want: `{"serial": 1}`,
+		},
+		{
+			name: "read state at reflog revision",
+			path: repository + "@HEAD@{1}:states/prod.tfstate",
+			want: `{"serial": 1}`,
+		},
+		{
+			name: "read state at head",
+			path: repository + "@HEAD:states/prod.tfstate",
+			want: `{"serial": 2}`,
+		},
+		{
+			name:    "read missing state",
+			path:    repository + "@v1:states/staging.tfstate",
+			wantErr: "Error reading state 'states/staging.tfstate' at revision 'v1' of git repository '" + repository + "': fatal:",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewGitReader(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ioutil.ReadAll(reader)
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.True(t, strings.HasPrefix(err.Error(), tt.wantErr), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, string(got))
+			assert.Nil(t, reader.Close())
+		})
+	}
+}
+
+func TestNewGitReader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "driftctl-git-backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	repository := filepath.Join(dir, "infra@2021")
+	if err := os.Mkdir(repository, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		want    *GitBackend
+		wantErr string
+	}{
+		{
+			name: "parse path",
+			path: "/src/infra@main:states/prod.tfstate",
+			want: &GitBackend{repository: "/src/infra", revision: "main", path: "states/prod.tfstate"},
+		},
+		{
+			name: "parse path with @ in repository",
+			path: repository + "@origin/main:prod.tfstate",
+			want: &GitBackend{repository: repository, revision: "origin/main", path: "prod.tfstate"},
+		},
+		{
+			name: "parse path with @ in revision",
+			path: "/src/infra@HEAD@{1}:states/prod.tfstate",
+			want: &GitBackend{repository: "/src/infra", revision: "HEAD@{1}", path: "states/prod.tfstate"},
+		},
+		{
+			name: "parse path with @ in repository and revision",
+			path: repository + "@main@{2021-01-01 10:00}:prod.tfstate",
+			want: &GitBackend{repository: repository, revision: "main@{2021-01-01 10:00}", path: "prod.tfstate"},
+		},
+		{
+			name:    "revision starting with a dash",
+			path:    "/src/infra@--output=/tmp/state:prod.tfstate",
+			wantErr: "Invalid git revision: --output=/tmp/state",
+		},
+		{
+			name:    "missing revision",
+			path:    "/src/infra:states/prod.tfstate",
+			wantErr: "Unable to parse git path: /src/infra:states/prod.tfstate. Must be REPO_PATH@REF:PATH/TO/STATE",
+		},
+		{
+			name:    "missing state path",
+			path:    "/src/infra@main",
+			wantErr: "Unable to parse git path: /src/infra@main. Must be REPO_PATH@REF:PATH/TO/STATE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGitReader(tt.path)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/pkg/errors"
+	"google.golang.org/api/option"
+)
+
+const BackendKeyGS = "gs"
+
+type GSBackend struct {
+	bucket string
+	key    string
+	reader io.ReadCloser
+	client *storage.Client
+}
+
+// NewGSClient creates a read only storage client using application default credentials.
+// Requests target the given endpoint when set, the STORAGE_EMULATOR_HOST emulator or Google APIs otherwise
+func NewGSClient(ctx context.Context, endpoint string) (*storage.Client, error) {
+	opts := []option.ClientOption{option.WithScopes(storage.ScopeReadOnly)}
+	if host := os.Getenv("STORAGE_EMULATOR_HOST"); endpoint == "" && host != "" {
+		endpoint = host
+		if !strings.Contains(endpoint, "://") {
+			endpoint = fmt.Sprintf("http://%s", endpoint)
+		}
+	}
+	if endpoint != "" {
+		opts = append(opts, option.WithEndpoint(fmt.Sprintf("%s/storage/v1/", strings.TrimSuffix(endpoint, "/"))))
+	}
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to create GS client")
+	}
+	return client, nil
+}
+
+func NewGSReader(path string, opts *Options) (*GSBackend, error) {
+	bucketPath := strings.Split(path, "/")
+	if len(bucketPath) < 2 {
+		return nil, errors.Errorf("Unable to parse GS path: %s. Must be BUCKET_NAME/PATH/TO/OBJECT", path)
+	}
+
+	client, err := NewGSClient(context.Background(), opts.GSEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GSBackend{
+		bucket: bucketPath[0],
+		key:    strings.Join(bucketPath[1:], "/"),
+		client: client,
+	}, nil
+}
+
+func (s *GSBackend) Read(p []byte) (n int, err error) {
+	if s.reader == nil {
+		reader, err := s.client.Bucket(s.bucket).Object(s.key).NewReader(context.Background())
+		if err != nil {
+			return 0, errors.Errorf(
+				"Error reading state '%s' from gs bucket '%s': %s",
+				s.key,
+				s.bucket,
+				err,
+			)
+		}
+		s.reader = reader
+	}
+	return s.reader.Read(p)
+}
+
+func (s *GSBackend) Close() error {
+	err := errors.New("Unable to close reader as nothing was opened")
+	if s.reader != nil {
+		err = s.reader.Close()
+	}
+	if clientErr := s.client.Close(); err == nil {
+		err = clientErr
+	}
+	return err
+}
@@ -0,0 +1,90 @@
+package backend
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGSReaderInvalid(t *testing.T) {
+	_, err := NewGSReader("foobar", &Options{})
+	assert.EqualError(t, err, "Unable to parse GS path: foobar. Must be BUCKET_NAME/PATH/TO/OBJECT")
+}
+
+func TestGSBackend_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "read state",
+			path: "bucket-name/a/nested/prefix/state.tfstate",
+			want: "{}",
+		},
+		{
+			name:    "state not found",
+			path:    "bucket-name/not-found.tfstate",
+			wantErr: "Error reading state 'not-found.tfstate' from gs bucket 'bucket-name': storage: object doesn't exist",
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bucket-name/a/nested/prefix/state.tfstate" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "{}")
+	}))
+	defer server.Close()
+
+	os.Setenv("STORAGE_EMULATOR_HOST", server.Listener.Addr().String())
+	defer os.Unsetenv("STORAGE_EMULATOR_HOST")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewGSReader(tt.path, &Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ioutil.ReadAll(reader)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, string(got))
+			assert.Nil(t, reader.Close())
+		})
+	}
+}
+
+func TestGSBackend_ReadFromEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bucket-name/state.tfstate" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "{}")
+	}))
+	defer server.Close()
+
+	// Emulator mode is only used to disable authentication, the endpoint takes precedence over its host
+	os.Setenv("STORAGE_EMULATOR_HOST", "127.0.0.1:1")
+	defer os.Unsetenv("STORAGE_EMULATOR_HOST")
+
+	reader, err := NewGSReader("bucket-name/state.tfstate", &Options{GSEndpoint: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, "{}", string(got))
+	assert.Nil(t, reader.Close())
+}
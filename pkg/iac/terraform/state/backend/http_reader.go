@@ -3,6 +3,7 @@ package backend
 import (
 	"bytes"
 	"io"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/pkg/errors"
@@ -17,7 +18,8 @@ type HTTPBackend struct {
 	reader io.ReadCloser
 }
 
-func NewHTTPReader(rawURL string, opts *Options) (*HTTPBackend, error) {
+// NewHTTPRequest creates a GET request carrying headers given with --headers
+func NewHTTPRequest(rawURL string, opts *Options) (*http.Request, error) {
 	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
 	if err != nil {
 		return nil, err
@@ -27,11 +29,21 @@ func NewHTTPReader(rawURL string, opts *Options) (*HTTPBackend, error) {
 		req.Header.Add(key, value)
 	}
 
+	return req, nil
+}
+
+func NewHTTPReader(rawURL string, opts *Options) (*HTTPBackend, error) {
+	req, err := NewHTTPRequest(rawURL, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	client := &http.Client{}
 	res, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
+	defer res.Body.Close()
 
 	buf := new(bytes.Buffer)
 	_, err = buf.ReadFrom(res.Body)
@@ -45,7 +57,8 @@ func NewHTTPReader(rawURL string, opts *Options) (*HTTPBackend, error) {
 		return nil, errors.Errorf("error requesting HTTP(s) backend state: status code: %d", res.StatusCode)
 	}
 
-	return &HTTPBackend{rawURL, res.Body}, nil
+	// Body has been read to be logged, the state is read from the buffer
+	return &HTTPBackend{rawURL, ioutil.NopCloser(buf)}, nil
 }
 
 func (h *HTTPBackend) Read(p []byte) (n int, err error) {
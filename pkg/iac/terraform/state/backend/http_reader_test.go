@@ -2,7 +2,11 @@ package backend
 
 import (
 	"errors"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -186,3 +190,25 @@ func TestHTTPBackend_Read(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPBackend_ReadState(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"version": 4}`)
+	}))
+	defer server.Close()
+
+	reader, err := NewHTTPReader(server.URL+"/terraform.tfstate", &Options{
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"version": 4}`, string(got))
+	assert.Nil(t, reader.Close())
+}
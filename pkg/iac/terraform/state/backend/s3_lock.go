@@ -0,0 +1,74 @@
+package backend
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/pkg/errors"
+)
+
+// LockInfo is the lock terraform writes while it runs an operation on a state
+type LockInfo struct {
+	ID        string
+	Operation string
+	Who       string
+	Version   string
+	Created   time.Time
+	Path      string
+}
+
+// S3LockReader reads locks of states stored by the s3 backend, terraform keeps them in a DynamoDB table
+type S3LockReader struct {
+	table  string
+	client dynamodbiface.DynamoDBAPI
+}
+
+func NewS3LockReader(table string) *S3LockReader {
+	sess := session.Must(session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	}))
+
+	awsConfig := aws.NewConfig()
+	for _, env := range []string{"AWS_ENDPOINT_URL_DYNAMODB", "AWS_ENDPOINT_URL"} {
+		if endpoint := os.Getenv(env); endpoint != "" {
+			awsConfig.WithEndpoint(endpoint)
+			break
+		}
+	}
+
+	return &S3LockReader{
+		table:  table,
+		client: dynamodb.New(sess, awsConfig),
+	}
+}
+
+// ReadLock returns the lock of a state given as BUCKET_NAME/PATH/TO/OBJECT, or nil when it is not locked
+func (l *S3LockReader) ReadLock(path string) (*LockInfo, error) {
+	output, err := l.client.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(l.table),
+		Key: map[string]*dynamodb.AttributeValue{
+			"LockID": {S: aws.String(path)},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to read lock of state '%s' from DynamoDB table '%s'", path, l.table)
+	}
+
+	// The table also holds state digests, under LockID suffixed with -md5, those items have no lock info
+	info, exists := output.Item["Info"]
+	if !exists || info.S == nil {
+		return nil, nil
+	}
+
+	lock := LockInfo{}
+	if err := json.Unmarshal([]byte(*info.S), &lock); err != nil {
+		return nil, errors.Wrapf(err, "Unable to decode lock of state '%s'", path)
+	}
+	return &lock, nil
+}
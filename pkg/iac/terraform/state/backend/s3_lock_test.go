@@ -0,0 +1,112 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestS3LockReader_ReadLock(t *testing.T) {
+	items := map[string]string{
+		"bucket/locked.tfstate":        `{"LockID": {"S": "bucket/locked.tfstate"}, "Info": {"S": "{\"ID\":\"1b7f4c3e\",\"Operation\":\"OperationTypeApply\",\"Who\":\"ci@runner\",\"Version\":\"0.15.0\",\"Created\":\"2021-03-02T10:04:05Z\",\"Path\":\"bucket/locked.tfstate\"}"}}`,
+		"bucket/unlocked.tfstate-md5":  `{"LockID": {"S": "bucket/unlocked.tfstate-md5"}, "Digest": {"S": "0a9d6c2e0a77b7e27dc27b1b0a0cba4a"}}`,
+		"bucket/invalid-lock.tfstate":  `{"LockID": {"S": "bucket/invalid-lock.tfstate"}, "Info": {"S": "{"}}`,
+		"bucket/unlocked-item.tfstate": `{"LockID": {"S": "bucket/unlocked-item.tfstate"}}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := struct {
+			TableName string
+			Key       map[string]map[string]string
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil || r.Header.Get("X-Amz-Target") != "DynamoDB_20120810.GetItem" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		if input.TableName != "terraform-locks" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"__type": "com.amazonaws.dynamodb.v20120810#ResourceNotFoundException", "message": "Requested resource not found"}`)
+			return
+		}
+		item, exists := items[input.Key["LockID"]["S"]]
+		if !exists {
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		fmt.Fprintf(w, `{"Item": %s}`, item)
+	}))
+	defer server.Close()
+
+	env := map[string]string{
+		"AWS_ENDPOINT_URL_DYNAMODB": server.URL,
+		"AWS_REGION":                "us-east-1",
+		"AWS_ACCESS_KEY_ID":         "test",
+		"AWS_SECRET_ACCESS_KEY":     "test",
+	}
+	for key, value := range env {
+		os.Setenv(key, value)
+		defer os.Unsetenv(key)
+	}
+
+	tests := []struct {
+		name    string
+		table   string
+		path    string
+		want    *LockInfo
+		wantErr string
+	}{
+		{
+			name:  "test locked state",
+			table: "terraform-locks",
+			path:  "bucket/locked.tfstate",
+			want: &LockInfo{
+				ID:        "1b7f4c3e",
+				Operation: "OperationTypeApply",
+				Who:       "ci@runner",
+				Version:   "0.15.0",
+				Created:   time.Date(2021, 3, 2, 10, 4, 5, 0, time.UTC),
+				Path:      "bucket/locked.tfstate",
+			},
+		},
+		{
+			name:  "test state without lock",
+			table: "terraform-locks",
+			path:  "bucket/unlocked.tfstate",
+		},
+		{
+			name:  "test lock item without lock info",
+			table: "terraform-locks",
+			path:  "bucket/unlocked-item.tfstate",
+		},
+		{
+			name:    "test invalid lock info",
+			table:   "terraform-locks",
+			path:    "bucket/invalid-lock.tfstate",
+			wantErr: "Unable to decode lock of state 'bucket/invalid-lock.tfstate': unexpected end of JSON input",
+		},
+		{
+			name:    "test missing table",
+			table:   "missing",
+			path:    "bucket/locked.tfstate",
+			wantErr: "Unable to read lock of state 'bucket/locked.tfstate' from DynamoDB table 'missing': ResourceNotFoundException: Requested resource not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewS3LockReader(tt.table).ReadLock(tt.path)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
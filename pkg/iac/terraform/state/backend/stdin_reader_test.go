@@ -0,0 +1,24 @@
+package backend
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStdinReader(t *testing.T) {
+	defer func(reader io.Reader) { stdin = reader }(stdin)
+	stdin = strings.NewReader(`{"version": 4}`)
+
+	reader, err := NewStdinReader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"version": 4}`, string(got))
+	assert.Nil(t, reader.Close())
+}
@@ -0,0 +1,135 @@
+package backend
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type terraformCredentialsFile struct {
+	Credentials map[string]struct {
+		Token string `json:"token"`
+	} `json:"credentials"`
+}
+
+// tfCloudToken returns the API token of an endpoint from TFE_TOKEN, or from the credentials of the endpoint host
+// in the terraform CLI configuration file or in the credentials.tfrc.json file written by terraform login
+func tfCloudToken(endpoint string) string {
+	if token := os.Getenv("TFE_TOKEN"); token != "" {
+		return token
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return ""
+	}
+	host := u.Hostname()
+
+	configFile, credentialsFile := terraformCLIConfigFiles()
+	token, err := readCLIConfigToken(configFile, host)
+	if err != nil {
+		warnCredentials(host, err)
+	}
+	if token != "" {
+		return token
+	}
+	token, err = readCredentialsFileToken(credentialsFile, host)
+	if err != nil {
+		warnCredentials(host, err)
+	}
+	return token
+}
+
+func warnCredentials(host string, err error) {
+	logrus.WithFields(logrus.Fields{
+		"host": host,
+		"err":  err.Error(),
+	}).Warn("Unable to read terraform CLI credentials")
+}
+
+// terraformCLIConfigFiles returns paths of the terraform CLI configuration file and of the credentials file
+func terraformCLIConfigFiles() (string, string) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = ""
+	}
+
+	configFile := os.Getenv("TF_CLI_CONFIG_FILE")
+	if configFile == "" && home != "" {
+		configFile = filepath.Join(home, ".terraformrc")
+	}
+
+	credentialsFile := ""
+	if home != "" {
+		credentialsFile = filepath.Join(home, ".terraform.d", "credentials.tfrc.json")
+	}
+	return configFile, credentialsFile
+}
+
+// readCLIConfigToken reads the token of a credentials "host" block of the terraform CLI configuration file
+func readCLIConfigToken(path, host string) (string, error) {
+	if !fileExists(path) {
+		return "", nil
+	}
+
+	file, diags := hclparse.NewParser().ParseHCLFile(path)
+	if diags.HasErrors() {
+		return "", errors.Errorf("Unable to parse %s: %s", path, diags.Error())
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return "", errors.Errorf("Unable to parse %s", path)
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type != "credentials" || len(block.Labels) != 1 || block.Labels[0] != host {
+			continue
+		}
+		attribute, exists := block.Body.Attributes["token"]
+		if !exists {
+			continue
+		}
+		value, diags := attribute.Expr.Value(nil)
+		if diags.HasErrors() {
+			return "", errors.Errorf("Unable to read token of %s credentials in %s: %s", host, path, diags.Error())
+		}
+		if value.IsNull() || !value.Type().Equals(cty.String) {
+			return "", errors.Errorf("Token of %s credentials in %s must be a string", host, path)
+		}
+		return value.AsString(), nil
+	}
+	return "", nil
+}
+
+// readCredentialsFileToken reads the token of a host from the credentials file written by terraform login
+func readCredentialsFileToken(path, host string) (string, error) {
+	if !fileExists(path) {
+		return "", nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	credentials := terraformCredentialsFile{}
+	if err := json.Unmarshal(content, &credentials); err != nil {
+		return "", errors.Wrapf(err, "Unable to parse %s", path)
+	}
+	return credentials.Credentials[host].Token, nil
+}
+
+func fileExists(path string) bool {
+	if path == "" {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
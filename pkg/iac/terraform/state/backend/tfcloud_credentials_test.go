@@ -0,0 +1,61 @@
+package backend
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTFCloudClient_Token(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     *Options
+		env      map[string]string
+		expected string
+	}{
+		{
+			name:     "token option",
+			opts:     &Options{TFCloudToken: "option-token"},
+			env:      map[string]string{"TFE_TOKEN": "env-token"},
+			expected: "option-token",
+		},
+		{
+			name:     "token from environment",
+			opts:     &Options{},
+			env:      map[string]string{"TFE_TOKEN": "env-token"},
+			expected: "env-token",
+		},
+		{
+			name:     "token from credentials file",
+			opts:     &Options{},
+			expected: "credentials-file-token",
+		},
+		{
+			name:     "token from CLI configuration file",
+			opts:     &Options{TFCloudEndpoint: "https://tfe.example.com/api/v2"},
+			env:      map[string]string{"TF_CLI_CONFIG_FILE": "testdata/tfcloud/terraformrc"},
+			expected: "cli-config-token",
+		},
+		{
+			name:     "no credentials for host",
+			opts:     &Options{TFCloudEndpoint: "https://other.example.com/api/v2"},
+			env:      map[string]string{"TF_CLI_CONFIG_FILE": "testdata/tfcloud/terraformrc"},
+			expected: "",
+		},
+	}
+
+	home := os.Getenv("HOME")
+	defer os.Setenv("HOME", home)
+	os.Setenv("HOME", "testdata/tfcloud/home")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				os.Setenv(key, value)
+				defer os.Unsetenv(key)
+			}
+			assert.Equal(t, tt.expected, NewTFCloudClient(tt.opts).token)
+		})
+	}
+}
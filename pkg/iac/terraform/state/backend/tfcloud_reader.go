@@ -0,0 +1,217 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/pkg/errors"
+)
+
+const BackendKeyTFCloud = "tfcloud"
+
+const DefaultTFCloudEndpoint = "https://app.terraform.io/api/v2"
+
+type TFCloudWorkspace struct {
+	Name string
+}
+
+type tfCloudWorkspaceData struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		Name string `json:"name"`
+	} `json:"attributes"`
+}
+
+type tfCloudWorkspaceResponse struct {
+	Data tfCloudWorkspaceData `json:"data"`
+}
+
+type tfCloudWorkspacesResponse struct {
+	Data []tfCloudWorkspaceData `json:"data"`
+	Meta struct {
+		Pagination struct {
+			NextPage *int `json:"next-page"`
+		} `json:"pagination"`
+	} `json:"meta"`
+}
+
+type tfCloudStateVersionResponse struct {
+	Data struct {
+		Attributes struct {
+			HostedStateDownloadURL string `json:"hosted-state-download-url"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+// TFCloudClient queries the Terraform Cloud (or Enterprise) API
+type TFCloudClient struct {
+	endpoint string
+	token    string
+	client   *http.Client
+}
+
+// NewTFCloudClient creates a client authenticated with the tfc-token option,
+// TFE_TOKEN or the credentials of the endpoint host in the terraform CLI configuration
+func NewTFCloudClient(opts *Options) *TFCloudClient {
+	endpoint := DefaultTFCloudEndpoint
+	if opts.TFCloudEndpoint != "" {
+		endpoint = strings.TrimSuffix(opts.TFCloudEndpoint, "/")
+	}
+	token := opts.TFCloudToken
+	if token == "" {
+		token = tfCloudToken(endpoint)
+	}
+	return &TFCloudClient{
+		endpoint: endpoint,
+		token:    token,
+		client:   &http.Client{},
+	}
+}
+
+// TFCloudOptions returns options to query the Terraform Cloud API storing the state of a source
+func TFCloudOptions(config config.SupplierConfig, opts *Options) *Options {
+	if config.TFCloudEndpoint == "" {
+		return opts
+	}
+	sourceOpts := Options{}
+	if opts != nil {
+		sourceOpts = *opts
+	}
+	sourceOpts.TFCloudEndpoint = config.TFCloudEndpoint
+	return &sourceOpts
+}
+
+// ListWorkspaces returns workspaces of an organization, optionally filtered by name and tags
+func (c *TFCloudClient) ListWorkspaces(organization, name string, tags []string) ([]TFCloudWorkspace, error) {
+	workspaces := make([]TFCloudWorkspace, 0)
+	page := 1
+	for {
+		query := url.Values{
+			"page[number]": {fmt.Sprint(page)},
+			"page[size]":   {"100"},
+		}
+		if name != "" {
+			query.Set("search[name]", name)
+		}
+		if len(tags) > 0 {
+			query.Set("search[tags]", strings.Join(tags, ","))
+		}
+
+		response := tfCloudWorkspacesResponse{}
+		err := c.getJSON(fmt.Sprintf("%s/organizations/%s/workspaces?%s", c.endpoint, url.PathEscape(organization), query.Encode()), &response)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, workspace := range response.Data {
+			workspaces = append(workspaces, TFCloudWorkspace{Name: workspace.Attributes.Name})
+		}
+
+		if response.Meta.Pagination.NextPage == nil {
+			return workspaces, nil
+		}
+		page = *response.Meta.Pagination.NextPage
+	}
+}
+
+// CurrentState downloads the current state version of a workspace
+func (c *TFCloudClient) CurrentState(organization, workspace string) (io.ReadCloser, error) {
+	workspaceResponse := tfCloudWorkspaceResponse{}
+	err := c.getJSON(fmt.Sprintf("%s/organizations/%s/workspaces/%s", c.endpoint, url.PathEscape(organization), url.PathEscape(workspace)), &workspaceResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	stateVersionResponse := tfCloudStateVersionResponse{}
+	err = c.getJSON(fmt.Sprintf("%s/workspaces/%s/current-state-version", c.endpoint, url.PathEscape(workspaceResponse.Data.ID)), &stateVersionResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	downloadURL := stateVersionResponse.Data.Attributes.HostedStateDownloadURL
+	if downloadURL == "" {
+		return nil, errors.New("no state download URL found for current state version")
+	}
+
+	return c.get(downloadURL)
+}
+
+func (c *TFCloudClient) getJSON(rawURL string, v interface{}) error {
+	body, err := c.get(rawURL)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	return json.NewDecoder(body).Decode(v)
+}
+
+func (c *TFCloudClient) get(rawURL string) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+	if c.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 400 {
+		res.Body.Close()
+		return nil, errors.Errorf("error requesting terraform cloud API: status code: %d", res.StatusCode)
+	}
+
+	return res.Body, nil
+}
+
+type TFCloudBackend struct {
+	organization string
+	workspace    string
+	reader       io.ReadCloser
+	client       *TFCloudClient
+}
+
+func NewTFCloudReader(path string, opts *Options) (*TFCloudBackend, error) {
+	orgWorkspace := strings.Split(path, "/")
+	if len(orgWorkspace) != 2 || orgWorkspace[0] == "" || orgWorkspace[1] == "" {
+		return nil, errors.Errorf("Unable to parse tfcloud path: %s. Must be ORGANIZATION/WORKSPACE", path)
+	}
+
+	return &TFCloudBackend{
+		organization: orgWorkspace[0],
+		workspace:    orgWorkspace[1],
+		client:       NewTFCloudClient(opts),
+	}, nil
+}
+
+func (t *TFCloudBackend) Read(p []byte) (n int, err error) {
+	if t.reader == nil {
+		reader, err := t.client.CurrentState(t.organization, t.workspace)
+		if err != nil {
+			return 0, errors.Errorf(
+				"Error reading state of workspace '%s' from organization '%s': %s",
+				t.workspace,
+				t.organization,
+				err,
+			)
+		}
+		t.reader = reader
+	}
+	return t.reader.Read(p)
+}
+
+func (t *TFCloudBackend) Close() error {
+	if t.reader != nil {
+		return t.reader.Close()
+	}
+	return errors.New("Unable to close reader as nothing was opened")
+}
@@ -0,0 +1,87 @@
+package backend
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTFCloudReaderInvalid(t *testing.T) {
+	for _, path := range []string{"org", "org/", "org/workspace/foo"} {
+		_, err := NewTFCloudReader(path, &Options{})
+		assert.EqualError(t, err, fmt.Sprintf("Unable to parse tfcloud path: %s. Must be ORGANIZATION/WORKSPACE", path))
+	}
+}
+
+func TestTFCloudBackend_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		token   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "read current state",
+			path:  "my-org/my-workspace",
+			token: "token",
+			want:  "{}",
+		},
+		{
+			name:    "unknown workspace",
+			path:    "my-org/unknown",
+			token:   "token",
+			wantErr: "Error reading state of workspace 'unknown' from organization 'my-org': error requesting terraform cloud API: status code: 404",
+		},
+		{
+			name:    "invalid token",
+			path:    "my-org/my-workspace",
+			token:   "invalid",
+			wantErr: "Error reading state of workspace 'my-workspace' from organization 'my-org': error requesting terraform cloud API: status code: 401",
+		},
+	}
+
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v2/organizations/my-org/workspaces/my-workspace":
+			fmt.Fprint(w, `{"data": {"id": "ws-123", "attributes": {"name": "my-workspace"}}}`)
+		case "/api/v2/workspaces/ws-123/current-state-version":
+			fmt.Fprintf(w, `{"data": {"attributes": {"hosted-state-download-url": "%s/archivist/state"}}}`, serverURL)
+		case "/archivist/state":
+			fmt.Fprint(w, "{}")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewTFCloudReader(tt.path, &Options{
+				TFCloudToken:    tt.token,
+				TFCloudEndpoint: fmt.Sprintf("%s/api/v2", server.URL),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ioutil.ReadAll(reader)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, string(got))
+			assert.Nil(t, reader.Close())
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package enumerator
+
+import (
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/pkg/errors"
+)
+
+type AzureRMEnumerator struct {
+	config config.SupplierConfig
+}
+
+func NewAzureRMEnumerator(config config.SupplierConfig) *AzureRMEnumerator {
+	return &AzureRMEnumerator{
+		config,
+	}
+}
+
+func (s *AzureRMEnumerator) Enumerate() ([]string, error) {
+	blobPath := strings.Split(s.config.Path, "/")
+	if len(blobPath) < 2 {
+		return nil, errors.Errorf("Unable to parse azurerm path: %s. Must be ACCOUNT/CONTAINER/PREFIX", s.config.Path)
+	}
+	account := blobPath[0]
+	container := blobPath[1]
+	prefix := strings.Join(blobPath[2:], "/")
+
+	client, err := backend.NewAzureBlobClient(account)
+	if err != nil {
+		return nil, err
+	}
+
+	blobs, err := client.ListBlobs(container, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(blobs))
+	for _, blob := range blobs {
+		if blob.Size > 0 {
+			keys = append(keys, strings.Join([]string{account, container, blob.Name}, "/"))
+		}
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,97 @@
+package enumerator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+)
+
+func TestAzureRMEnumerator_Enumerate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config config.SupplierConfig
+		want   []string
+		err    string
+	}{
+		{
+			name: "test results are returned",
+			config: config.SupplierConfig{
+				Path: "devstoreaccount1/states/a/nested/prefix",
+			},
+			want: []string{
+				"devstoreaccount1/states/a/nested/prefix/state1",
+				"devstoreaccount1/states/a/nested/prefix/state2",
+				"devstoreaccount1/states/a/nested/prefix/state3",
+			},
+		},
+		{
+			name: "test invalid path",
+			config: config.SupplierConfig{
+				Path: "devstoreaccount1",
+			},
+			err: "Unable to parse azurerm path: devstoreaccount1. Must be ACCOUNT/CONTAINER/PREFIX",
+		},
+		{
+			name: "test unknown container",
+			config: config.SupplierConfig{
+				Path: "devstoreaccount1/unknown",
+			},
+			err: "ContainerNotFound (404)",
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/devstoreaccount1/states" || query.Get("comp") != "list" || query.Get("prefix") != "a/nested/prefix" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><Error><Code>ContainerNotFound</Code></Error>`)
+			return
+		}
+		if query.Get("marker") == "" {
+			fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?>
+<EnumerationResults>
+  <Blobs>
+    <Blob><Name>a/nested/prefix/state1</Name><Properties><Content-Length>5</Content-Length></Properties></Blob>
+    <Blob><Name>a/nested/prefix/empty</Name><Properties><Content-Length>0</Content-Length></Properties></Blob>
+    <Blob><Name>a/nested/prefix/state2</Name><Properties><Content-Length>2</Content-Length></Properties></Blob>
+  </Blobs>
+  <NextMarker>page2</NextMarker>
+</EnumerationResults>`)
+			return
+		}
+		fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?>
+<EnumerationResults>
+  <Blobs>
+    <Blob><Name>a/nested/prefix/state3</Name><Properties><Content-Length>1</Content-Length></Properties></Blob>
+  </Blobs>
+  <NextMarker />
+</EnumerationResults>`)
+	}))
+	defer server.Close()
+
+	os.Setenv("AZURE_STORAGE_ENDPOINT", fmt.Sprintf("%s/devstoreaccount1", server.URL))
+	os.Setenv("AZURE_STORAGE_SAS_TOKEN", "sv=2019-12-12&sig=signature")
+	defer os.Unsetenv("AZURE_STORAGE_ENDPOINT")
+	defer os.Unsetenv("AZURE_STORAGE_SAS_TOKEN")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewAzureRMEnumerator(tt.config)
+			got, err := s.Enumerate()
+			if err != nil && err.Error() != tt.err {
+				t.Fatalf("Expected error '%s', got '%s'", tt.err, err.Error())
+			}
+			if err == nil && tt.err != "" {
+				t.Fatalf("Expected error '%s' but got nil", tt.err)
+			}
+			if tt.err == "" && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Enumerate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
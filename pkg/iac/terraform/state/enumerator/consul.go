@@ -0,0 +1,41 @@
+package enumerator
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+)
+
+// Keys written by terraform consul backend next to states: locks and chunks of large states
+var consulNonStateKeyRegex = regexp.MustCompile(`(/\.lock|/\.lockinfo|/tfstate\.[0-9a-f]+/[0-9]+)$`)
+
+type ConsulEnumerator struct {
+	config config.SupplierConfig
+	client *backend.ConsulClient
+}
+
+func NewConsulEnumerator(config config.SupplierConfig) *ConsulEnumerator {
+	return &ConsulEnumerator{
+		config,
+		backend.NewConsulClient(),
+	}
+}
+
+func (s *ConsulEnumerator) Enumerate() ([]string, error) {
+	keys, err := s.client.Keys(s.config.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	stateKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if strings.HasSuffix(key, "/") || consulNonStateKeyRegex.MatchString(key) {
+			continue
+		}
+		stateKeys = append(stateKeys, key)
+	}
+
+	return stateKeys, nil
+}
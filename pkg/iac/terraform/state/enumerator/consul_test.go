@@ -0,0 +1,72 @@
+package enumerator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+)
+
+func TestConsulEnumerator_Enumerate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/kv/states/" || r.URL.Query()["keys"] == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `[
+			"states/",
+			"states/network",
+			"states/network/.lock",
+			"states/network/.lockinfo",
+			"states/dns",
+			"states/dns/tfstate.0cc175b9c0f1b6a831c399e269772661/0",
+			"states/dns/tfstate.0cc175b9c0f1b6a831c399e269772661/1",
+			"states/nested/app"
+		]`)
+	}))
+	defer server.Close()
+
+	os.Setenv("CONSUL_HTTP_ADDR", server.URL)
+	defer os.Unsetenv("CONSUL_HTTP_ADDR")
+
+	tests := []struct {
+		name   string
+		config config.SupplierConfig
+		want   []string
+	}{
+		{
+			name: "test states are returned",
+			config: config.SupplierConfig{
+				Path: "states/",
+			},
+			want: []string{
+				"states/network",
+				"states/dns",
+				"states/nested/app",
+			},
+		},
+		{
+			name: "test unknown prefix",
+			config: config.SupplierConfig{
+				Path: "unknown/",
+			},
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewConsulEnumerator(tt.config)
+			got, err := s.Enumerate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Enumerate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
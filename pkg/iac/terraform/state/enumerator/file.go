@@ -29,8 +29,20 @@ func NewFileEnumerator(config config.SupplierConfig) *FileEnumerator {
 // to follow links, but it allows infinite loop so be careful !
 // If a symlink is given as root path, we will follow it, but symlinks under this path
 // will not be resolved.
+// When the path contains a glob pattern, we walk from its static root directory and only keep
+// files matching the pattern, e.g. stacks/**/terraform.tfstate
 func (s *FileEnumerator) Enumerate() ([]string, error) {
 	path := s.config.Path
+	pattern := ""
+	if HasGlob(path) {
+		path, pattern = SplitGlob(filepath.ToSlash(filepath.Clean(path)))
+		if path == "" && filepath.IsAbs(s.config.Path) {
+			path = "/"
+		} else if path == "" {
+			path = "."
+		}
+	}
+
 	info, err := os.Lstat(path)
 	if err != nil {
 		return nil, err
@@ -54,8 +66,9 @@ func (s *FileEnumerator) Enumerate() ([]string, error) {
 	}
 
 	keys := make([]string, 0)
+	root := path
 
-	err = filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
 		// Not tested, we should remove a file or folder after WalkDir has enumerated the whole tree in memory
 		// This edge case does not really need to be covered by tests
 		if err != nil {
@@ -71,9 +84,21 @@ func (s *FileEnumerator) Enumerate() ([]string, error) {
 			return nil
 		}
 
-		if !d.IsDir() {
-			keys = append(keys, path)
+		if d.IsDir() {
+			return nil
 		}
+
+		if pattern != "" {
+			relativePath, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			if !MatchGlob(pattern, filepath.ToSlash(relativePath)) {
+				return nil
+			}
+		}
+
+		keys = append(keys, path)
 		return nil
 	})
 
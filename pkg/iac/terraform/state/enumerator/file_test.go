@@ -54,6 +54,42 @@ func TestFileEnumerator_Enumerate(t *testing.T) {
 				"testdata/states/terraform.tfstate",
 			},
 		},
+		{
+			name: "glob pattern",
+			config: config.SupplierConfig{
+				Path: "testdata/states/**/*.tfstate",
+			},
+			want: []string{
+				"testdata/states/s3/terraform.tfstate",
+				"testdata/states/terraform.tfstate",
+			},
+		},
+		{
+			name: "glob pattern in subfolder",
+			config: config.SupplierConfig{
+				Path: "testdata/states/*/directory/*.state",
+			},
+			want: []string{
+				"testdata/states/route53/directory/route53.state",
+			},
+		},
+		{
+			name: "glob pattern in symlinked folder",
+			config: config.SupplierConfig{
+				Path: "testdata/symlink/**/terraform.tfstate",
+			},
+			want: []string{
+				"testdata/states/s3/terraform.tfstate",
+				"testdata/states/terraform.tfstate",
+			},
+		},
+		{
+			name: "glob pattern without match",
+			config: config.SupplierConfig{
+				Path: "testdata/states/*.json",
+			},
+			want: []string{},
+		},
 		{
 			name: "invalid folder",
 			config: config.SupplierConfig{
@@ -0,0 +1,51 @@
+package enumerator
+
+import (
+	"path"
+	"strings"
+)
+
+// HasGlob reports whether a path contains glob pattern characters
+func HasGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// SplitGlob splits a pattern in a static prefix, made of the segments before the first one containing
+// a glob character, and the remaining pattern
+// e.g. envs/*/network/*.tfstate => envs, */network/*.tfstate
+func SplitGlob(pattern string) (string, string) {
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		if HasGlob(segment) {
+			return strings.Join(segments[:i], "/"), strings.Join(segments[i:], "/")
+		}
+	}
+	return pattern, ""
+}
+
+// MatchGlob reports whether a slash separated name matches a pattern.
+// Segments follow path.Match syntax, a ** segment matches zero or more segments
+func MatchGlob(pattern, name string) bool {
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchGlobSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchGlobSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if match, _ := path.Match(pattern[0], name[0]); !match {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
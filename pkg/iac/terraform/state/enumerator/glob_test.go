@@ -0,0 +1,51 @@
+package enumerator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitGlob(t *testing.T) {
+	tests := []struct {
+		pattern     string
+		wantPrefix  string
+		wantPattern string
+	}{
+		{pattern: "envs/*/network/*.tfstate", wantPrefix: "envs", wantPattern: "*/network/*.tfstate"},
+		{pattern: "stacks/**/terraform.tfstate", wantPrefix: "stacks", wantPattern: "**/terraform.tfstate"},
+		{pattern: "*.tfstate", wantPrefix: "", wantPattern: "*.tfstate"},
+		{pattern: "/tmp/states/prod-?.tfstate", wantPrefix: "/tmp/states", wantPattern: "prod-?.tfstate"},
+		{pattern: "states/terraform.tfstate", wantPrefix: "states/terraform.tfstate", wantPattern: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			prefix, pattern := SplitGlob(tt.pattern)
+			assert.Equal(t, tt.wantPrefix, prefix)
+			assert.Equal(t, tt.wantPattern, pattern)
+		})
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "envs/*/network/*.tfstate", name: "envs/prod/network/main.tfstate", want: true},
+		{pattern: "envs/*/network/*.tfstate", name: "envs/prod/network/main.tfstate.backup", want: false},
+		{pattern: "envs/*/network/*.tfstate", name: "envs/prod/eu/network/main.tfstate", want: false},
+		{pattern: "stacks/**/terraform.tfstate", name: "stacks/terraform.tfstate", want: true},
+		{pattern: "stacks/**/terraform.tfstate", name: "stacks/a/b/c/terraform.tfstate", want: true},
+		{pattern: "stacks/**/terraform.tfstate", name: "stacks/a/b/c/other.json", want: false},
+		{pattern: "**", name: "a/b/c", want: true},
+		{pattern: "prod-[ab].tfstate", name: "prod-a.tfstate", want: true},
+		{pattern: "prod-[ab].tfstate", name: "prod-c.tfstate", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchGlob(tt.pattern, tt.name))
+		})
+	}
+}
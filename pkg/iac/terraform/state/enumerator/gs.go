@@ -0,0 +1,57 @@
+package enumerator
+
+import (
+	"context"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/pkg/errors"
+	"google.golang.org/api/iterator"
+)
+
+type GSEnumerator struct {
+	config config.SupplierConfig
+	opts   *backend.Options
+}
+
+func NewGSEnumerator(config config.SupplierConfig, opts *backend.Options) *GSEnumerator {
+	return &GSEnumerator{
+		config,
+		opts,
+	}
+}
+
+func (s *GSEnumerator) Enumerate() ([]string, error) {
+	bucketPath := strings.Split(s.config.Path, "/")
+	if len(bucketPath) < 2 {
+		return nil, errors.Errorf("Unable to parse GS path: %s. Must be BUCKET_NAME/PREFIX", s.config.Path)
+	}
+	bucket := bucketPath[0]
+	prefix := strings.Join(bucketPath[1:], "/")
+
+	ctx := context.Background()
+	client, err := backend.NewGSClient(ctx, s.opts.GSEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	keys := make([]string, 0)
+	it := client.Bucket(bucket).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if attrs.Size > 0 {
+			keys = append(keys, strings.Join([]string{bucket, attrs.Name}, "/"))
+		}
+	}
+
+	return keys, nil
+}
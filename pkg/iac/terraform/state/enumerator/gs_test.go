@@ -0,0 +1,97 @@
+package enumerator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+)
+
+func TestGSEnumerator_Enumerate(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   config.SupplierConfig
+		endpoint bool
+		want     []string
+		err      string
+	}{
+		{
+			name: "test results are returned",
+			config: config.SupplierConfig{
+				Path: "bucket-name/a/nested/prefix",
+			},
+			want: []string{
+				"bucket-name/a/nested/prefix/state1",
+				"bucket-name/a/nested/prefix/state2",
+				"bucket-name/a/nested/prefix/state3",
+			},
+		},
+		{
+			name: "test results are returned from endpoint",
+			config: config.SupplierConfig{
+				Path: "bucket-name/a/nested/prefix",
+			},
+			endpoint: true,
+			want: []string{
+				"bucket-name/a/nested/prefix/state1",
+				"bucket-name/a/nested/prefix/state2",
+				"bucket-name/a/nested/prefix/state3",
+			},
+		},
+		{
+			name: "test invalid path",
+			config: config.SupplierConfig{
+				Path: "bucket-name",
+			},
+			err: "Unable to parse GS path: bucket-name. Must be BUCKET_NAME/PREFIX",
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/storage/v1/b/bucket-name/o" || r.URL.Query().Get("prefix") != "a/nested/prefix" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("pageToken") == "" {
+			fmt.Fprint(w, `{"nextPageToken": "page2", "items": [
+				{"name": "a/nested/prefix/", "size": "0"},
+				{"name": "a/nested/prefix/state1", "size": "5"},
+				{"name": "a/nested/prefix/state2", "size": "2"}
+			]}`)
+			return
+		}
+		fmt.Fprint(w, `{"items": [{"name": "a/nested/prefix/state3", "size": "1"}]}`)
+	}))
+	defer server.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &backend.Options{}
+			if tt.endpoint {
+				// Emulator mode is only used to disable authentication, the endpoint takes precedence over its host
+				os.Setenv("STORAGE_EMULATOR_HOST", "127.0.0.1:1")
+				opts.GSEndpoint = server.URL
+			} else {
+				os.Setenv("STORAGE_EMULATOR_HOST", server.Listener.Addr().String())
+			}
+			defer os.Unsetenv("STORAGE_EMULATOR_HOST")
+
+			s := NewGSEnumerator(tt.config, opts)
+			got, err := s.Enumerate()
+			if err != nil && err.Error() != tt.err {
+				t.Fatalf("Expected error '%s', got '%s'", tt.err, err.Error())
+			}
+			if err != nil && tt.err == "" {
+				t.Fatalf("Expected error '%s' but got nil", tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Enumerate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
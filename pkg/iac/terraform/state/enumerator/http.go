@@ -0,0 +1,222 @@
+package enumerator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/html"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+)
+
+type HTTPEnumerator struct {
+	config config.SupplierConfig
+	opts   *backend.Options
+	client *http.Client
+}
+
+func NewHTTPEnumerator(config config.SupplierConfig, opts *backend.Options) *HTTPEnumerator {
+	return &HTTPEnumerator{
+		config: config,
+		opts:   opts,
+		client: &http.Client{},
+	}
+}
+
+// Enumerate fetches the configured URL, when it is an index every state it references is returned.
+// An index is either a JSON list of state URLs, or an HTML directory listing whose sub directories
+// are listed as well. Any other document is considered as a single state.
+// Documents are only fetched when they may be an index, states are downloaded once by the backend.
+func (e *HTTPEnumerator) Enumerate() ([]string, error) {
+	indexURL, err := url.Parse(fmt.Sprintf("%s://%s", e.config.Backend, e.config.Path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to parse HTTP index URL")
+	}
+
+	if !e.mayBeIndex(indexURL) {
+		return []string{e.config.Path}, nil
+	}
+
+	urls, isIndex, err := e.readIndex(indexURL, map[string]struct{}{})
+	if err != nil {
+		return nil, err
+	}
+	if !isIndex {
+		return []string{e.config.Path}, nil
+	}
+
+	keys := make([]string, 0, len(urls))
+	seen := make(map[string]struct{})
+	for _, stateURL := range urls {
+		// Keys are read by a backend of the same scheme
+		if stateURL.Scheme != e.config.Backend {
+			logrus.WithFields(logrus.Fields{
+				"url": stateURL.String(),
+			}).Warnf("Ignored state referenced by HTTP index as it is not served over %s", e.config.Backend)
+			continue
+		}
+		key := strings.TrimPrefix(stateURL.String(), stateURL.Scheme+"://")
+		if _, exists := seen[key]; exists {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// mayBeIndex tells whether a document should be fetched as an index.
+// Directories are always listed, other documents are only fetched when served as JSON or HTML.
+// Errors are not reported here, the backend reports them when reading the state.
+func (e *HTTPEnumerator) mayBeIndex(indexURL *url.URL) bool {
+	if strings.HasSuffix(indexURL.Path, "/") {
+		return true
+	}
+
+	req, err := backend.NewHTTPRequest(indexURL.String(), e.opts)
+	if err != nil {
+		return false
+	}
+	req.Method = http.MethodHead
+	res, err := e.client.Do(req)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"url": indexURL.String(),
+			"err": err,
+		}).Debug("Unable to probe HTTP document, it is read as a single state")
+		return false
+	}
+	res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 400 {
+		return false
+	}
+	contentType := res.Header.Get("Content-Type")
+	return strings.HasPrefix(contentType, "application/json") || strings.HasPrefix(contentType, "text/html")
+}
+
+// readIndex returns URLs referenced by an index, or false when the document is not an index
+func (e *HTTPEnumerator) readIndex(indexURL *url.URL, visited map[string]struct{}) ([]*url.URL, bool, error) {
+	visited[indexURL.String()] = struct{}{}
+
+	req, err := backend.NewHTTPRequest(indexURL.String(), e.opts)
+	if err != nil {
+		return nil, false, err
+	}
+	res, err := e.client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 400 {
+		return nil, false, errors.Errorf("error requesting HTTP(s) index %s: status code: %d", indexURL, res.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	body = bytes.TrimSpace(body)
+
+	switch {
+	case bytes.HasPrefix(body, []byte("[")):
+		urls, err := readJSONIndex(indexURL, body)
+		return urls, true, err
+	case strings.HasPrefix(res.Header.Get("Content-Type"), "text/html") || bytes.HasPrefix(body, []byte("<")):
+		urls, err := e.readDirectoryListing(indexURL, body, visited)
+		return urls, true, err
+	}
+
+	return nil, false, nil
+}
+
+func readJSONIndex(indexURL *url.URL, body []byte) ([]*url.URL, error) {
+	entries := make([]string, 0)
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, errors.Wrapf(err, "Unable to decode HTTP index %s, it must be a list of URLs", indexURL)
+	}
+
+	urls := make([]*url.URL, 0, len(entries))
+	for _, entry := range entries {
+		ref, err := indexURL.Parse(entry)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Unable to parse URL '%s' of HTTP index %s", entry, indexURL)
+		}
+		urls = append(urls, ref)
+	}
+	return urls, nil
+}
+
+// readDirectoryListing returns files linked from a directory listing, and files of its sub directories.
+// Only links under the listed directory are followed, parent directory and sorting links are ignored.
+func (e *HTTPEnumerator) readDirectoryListing(indexURL *url.URL, body []byte, visited map[string]struct{}) ([]*url.URL, error) {
+	dir := indexURL.Path
+	if !strings.HasSuffix(dir, "/") {
+		dir = path.Dir(dir) + "/"
+	}
+
+	urls := make([]*url.URL, 0)
+	for _, href := range readLinks(body) {
+		ref, err := indexURL.Parse(href)
+		if err != nil {
+			continue
+		}
+		ref.Fragment = ""
+		if ref.Host != indexURL.Host || ref.RawQuery != "" || !strings.HasPrefix(ref.Path, dir) || ref.Path == dir {
+			continue
+		}
+
+		if strings.HasSuffix(ref.Path, "/") {
+			if _, exists := visited[ref.String()]; exists {
+				continue
+			}
+			subURLs, isIndex, err := e.readIndex(ref, visited)
+			if err != nil {
+				return nil, err
+			}
+			if isIndex {
+				urls = append(urls, subURLs...)
+			}
+			continue
+		}
+
+		// Ignore .backup files generated by terraform
+		if strings.HasSuffix(ref.Path, ".backup") {
+			continue
+		}
+		urls = append(urls, ref)
+	}
+	return urls, nil
+}
+
+func readLinks(body []byte) []string {
+	links := make([]string, 0)
+	tokenizer := html.NewTokenizer(bytes.NewReader(body))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return links
+		case html.StartTagToken:
+			token := tokenizer.Token()
+			if token.Data != "a" {
+				continue
+			}
+			for _, attr := range token.Attr {
+				if attr.Key == "href" {
+					links = append(links, attr.Val)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package enumerator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+)
+
+func TestHTTPEnumerator_Enumerate(t *testing.T) {
+	documents := map[string]string{
+		"/index.json": `[
+			"states/network.tfstate",
+			"/states/dns.tfstate",
+			"{{server}}/states/app.tfstate",
+			"https://example.com/states/remote.tfstate",
+			"states/network.tfstate"
+		]`,
+		"/listing/": `<!DOCTYPE HTML PUBLIC "-//W3C//DTD HTML 3.2 Final//EN">
+			<html><head><title>Index of /listing</title></head><body>
+			<h1>Index of /listing</h1>
+			<table>
+			<tr><th><a href="?C=N;O=D">Name</a></th><th><a href="?C=M;O=A">Last modified</a></th></tr>
+			<tr><td><a href="/">Parent Directory</a></td></tr>
+			<tr><td><a href="network.tfstate">network.tfstate</a></td></tr>
+			<tr><td><a href="network.tfstate.backup">network.tfstate.backup</a></td></tr>
+			<tr><td><a href="prod/">prod/</a></td></tr>
+			<tr><td><a href="https://example.com/listing/remote.tfstate">remote.tfstate</a></td></tr>
+			</table></body></html>`,
+		"/listing/prod/": `<html><body><pre>
+			<a href="../">../</a>
+			<a href="app.tfstate">app.tfstate</a>
+			<a href="/listing/prod/">./</a>
+			</pre></body></html>`,
+		"/terraform.tfstate": `{"version": 4}`,
+		"/state.json":        `{"version": 4}`,
+		"/invalid.json":      `["states/network.tfstate",`,
+	}
+
+	downloads := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		document, exists := documents[r.URL.Path]
+		if !exists || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			downloads[r.URL.Path]++
+		}
+		if strings.HasSuffix(r.URL.Path, "/") {
+			w.Header().Set("Content-Type", "text/html")
+		}
+		if strings.HasSuffix(r.URL.Path, ".json") {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		fmt.Fprint(w, strings.ReplaceAll(document, "{{server}}", "http://"+r.Host))
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	tests := []struct {
+		name          string
+		path          string
+		want          []string
+		wantDownloads int
+		wantErr       string
+	}{
+		{
+			name: "test states of json index are returned",
+			path: host + "/index.json",
+			want: []string{
+				host + "/states/network.tfstate",
+				host + "/states/dns.tfstate",
+				host + "/states/app.tfstate",
+			},
+			wantDownloads: 1,
+		},
+		{
+			name: "test states of directory listing and sub directories are returned",
+			path: host + "/listing/",
+			want: []string{
+				host + "/listing/network.tfstate",
+				host + "/listing/prod/app.tfstate",
+			},
+			wantDownloads: 1,
+		},
+		{
+			name: "test single state is not downloaded",
+			path: host + "/terraform.tfstate",
+			want: []string{
+				host + "/terraform.tfstate",
+			},
+		},
+		{
+			name: "test single state served as json",
+			path: host + "/state.json",
+			want: []string{
+				host + "/state.json",
+			},
+			wantDownloads: 1,
+		},
+		{
+			name: "test missing state is left to the backend",
+			path: host + "/missing.tfstate",
+			want: []string{
+				host + "/missing.tfstate",
+			},
+		},
+		{
+			name:    "test invalid json index",
+			path:    host + "/invalid.json",
+			wantErr: fmt.Sprintf("Unable to decode HTTP index http://%s/invalid.json, it must be a list of URLs: unexpected end of JSON input", host),
+		},
+		{
+			name:    "test missing index",
+			path:    host + "/missing/",
+			wantErr: fmt.Sprintf("error requesting HTTP(s) index http://%s/missing/: status code: 404", host),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			downloads = make(map[string]int)
+			e := NewHTTPEnumerator(
+				config.SupplierConfig{Backend: backend.BackendKeyHTTP, Path: tt.path},
+				&backend.Options{Headers: map[string]string{"Authorization": "Bearer token"}},
+			)
+			got, err := e.Enumerate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+			path := strings.TrimPrefix(tt.path, host)
+			assert.Equal(t, tt.wantDownloads, downloads[path])
+		})
+	}
+}
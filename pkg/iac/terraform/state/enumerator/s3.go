@@ -1,6 +1,7 @@
 package enumerator
 
 import (
+	"path"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -11,6 +12,8 @@ import (
 	"github.com/pkg/errors"
 )
 
+const DefaultWorkspaceKeyPrefix = "env:"
+
 type S3EnumeratorConfig struct {
 	Bucket *string
 	Prefix *string
@@ -39,6 +42,65 @@ func (s *S3Enumerator) Enumerate() ([]string, error) {
 	bucket := bucketPath[0]
 	prefix := strings.Join(bucketPath[1:], "/")
 
+	if len(s.config.Workspaces) > 0 {
+		return s.enumerateWorkspaces(bucket, prefix)
+	}
+
+	// When the key is a glob pattern, list objects under its static part and match keys against the pattern
+	if HasGlob(prefix) {
+		return s.listMatchingObjects(bucket, prefix)
+	}
+
+	return s.listObjects(bucket, prefix, nil)
+}
+
+// enumerateWorkspaces returns states of every workspace matching configured workspaces patterns.
+// Terraform stores the default workspace state under its key, and other ones under WORKSPACE_KEY_PREFIX/WORKSPACE/KEY
+func (s *S3Enumerator) enumerateWorkspaces(bucket, key string) ([]string, error) {
+	workspaceKeyPrefix := s.config.WorkspaceKeyPrefix
+	if workspaceKeyPrefix == "" {
+		workspaceKeyPrefix = DefaultWorkspaceKeyPrefix
+	}
+
+	patterns := make([]string, 0, len(s.config.Workspaces))
+	for _, workspace := range s.config.Workspaces {
+		if match, _ := path.Match(workspace, "default"); match {
+			patterns = append(patterns, key)
+		}
+		if workspace != "default" {
+			patterns = append(patterns, strings.Join([]string{workspaceKeyPrefix, workspace, key}, "/"))
+		}
+	}
+
+	keys := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, pattern := range patterns {
+		patternKeys, err := s.listMatchingObjects(bucket, pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range patternKeys {
+			if _, exists := seen[key]; !exists {
+				seen[key] = struct{}{}
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	return keys, nil
+}
+
+func (s *S3Enumerator) listMatchingObjects(bucket, pattern string) ([]string, error) {
+	prefix, _ := SplitGlob(pattern)
+	if prefix != "" && prefix != pattern {
+		prefix += "/"
+	}
+	return s.listObjects(bucket, prefix, func(key string) bool {
+		return MatchGlob(pattern, key)
+	})
+}
+
+func (s *S3Enumerator) listObjects(bucket, prefix string, match func(key string) bool) ([]string, error) {
 	keys := make([]string, 0)
 	input := &s3.ListObjectsV2Input{
 		Bucket: &bucket,
@@ -46,6 +108,9 @@ func (s *S3Enumerator) Enumerate() ([]string, error) {
 	}
 	err := s.client.ListObjectsV2Pages(input, func(output *s3.ListObjectsV2Output, lastPage bool) bool {
 		for _, metadata := range output.Contents {
+			if match != nil && !match(*metadata.Key) {
+				continue
+			}
 			if aws.Int64Value(metadata.Size) > 0 {
 				keys = append(keys, strings.Join([]string{bucket, *metadata.Key}, "/"))
 			}
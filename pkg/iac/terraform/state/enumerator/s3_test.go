@@ -121,6 +121,200 @@ func TestS3Enumerator_Enumerate(t *testing.T) {
 				"bucket-name/a/nested/prefix/state4",
 			},
 		},
+		{
+			name: "test glob pattern",
+			config: config.SupplierConfig{
+				Path: "bucket-name/envs/*/network/*.tfstate",
+			},
+			mocks: func(client *mocks.FakeS3) {
+				input := &s3.ListObjectsV2Input{
+					Bucket: awssdk.String("bucket-name"),
+					Prefix: awssdk.String("envs/"),
+				}
+				client.On(
+					"ListObjectsV2Pages",
+					input,
+					mock.MatchedBy(func(callback func(res *s3.ListObjectsV2Output, lastPage bool) bool) bool {
+						callback(&s3.ListObjectsV2Output{
+							Contents: []*s3.Object{
+								{
+									Key:  awssdk.String("envs/prod/network/terraform.tfstate"),
+									Size: awssdk.Int64(5),
+								},
+								{
+									Key:  awssdk.String("envs/prod/network/terraform.tfstate.backup"),
+									Size: awssdk.Int64(5),
+								},
+								{
+									Key:  awssdk.String("envs/prod/network/outputs.json"),
+									Size: awssdk.Int64(5),
+								},
+								{
+									Key:  awssdk.String("envs/prod/compute/terraform.tfstate"),
+									Size: awssdk.Int64(5),
+								},
+								{
+									Key:  awssdk.String("envs/staging/network/terraform.tfstate"),
+									Size: awssdk.Int64(5),
+								},
+								{
+									Key:  awssdk.String("envs/staging/eu/network/terraform.tfstate"),
+									Size: awssdk.Int64(5),
+								},
+							},
+						}, true)
+						return true
+					}),
+				).Return(nil)
+			},
+			want: []string{
+				"bucket-name/envs/prod/network/terraform.tfstate",
+				"bucket-name/envs/staging/network/terraform.tfstate",
+			},
+		},
+		{
+			name: "test recursive glob pattern at bucket root",
+			config: config.SupplierConfig{
+				Path: "bucket-name/**/terraform.tfstate",
+			},
+			mocks: func(client *mocks.FakeS3) {
+				input := &s3.ListObjectsV2Input{
+					Bucket: awssdk.String("bucket-name"),
+					Prefix: awssdk.String(""),
+				}
+				client.On(
+					"ListObjectsV2Pages",
+					input,
+					mock.MatchedBy(func(callback func(res *s3.ListObjectsV2Output, lastPage bool) bool) bool {
+						callback(&s3.ListObjectsV2Output{
+							Contents: []*s3.Object{
+								{
+									Key:  awssdk.String("terraform.tfstate"),
+									Size: awssdk.Int64(5),
+								},
+								{
+									Key:  awssdk.String("a/b/c/terraform.tfstate"),
+									Size: awssdk.Int64(5),
+								},
+								{
+									Key:  awssdk.String("a/b/c/other.tfstate"),
+									Size: awssdk.Int64(5),
+								},
+							},
+						}, true)
+						return true
+					}),
+				).Return(nil)
+			},
+			want: []string{
+				"bucket-name/terraform.tfstate",
+				"bucket-name/a/b/c/terraform.tfstate",
+			},
+		},
+		{
+			name: "test workspaces",
+			config: config.SupplierConfig{
+				Path:       "bucket-name/network/terraform.tfstate",
+				Workspaces: []string{"default", "prod"},
+			},
+			mocks: func(client *mocks.FakeS3) {
+				client.On(
+					"ListObjectsV2Pages",
+					&s3.ListObjectsV2Input{
+						Bucket: awssdk.String("bucket-name"),
+						Prefix: awssdk.String("network/terraform.tfstate"),
+					},
+					mock.MatchedBy(func(callback func(res *s3.ListObjectsV2Output, lastPage bool) bool) bool {
+						callback(&s3.ListObjectsV2Output{
+							Contents: []*s3.Object{
+								{
+									Key:  awssdk.String("network/terraform.tfstate"),
+									Size: awssdk.Int64(5),
+								},
+								{
+									Key:  awssdk.String("network/terraform.tfstate.backup"),
+									Size: awssdk.Int64(5),
+								},
+							},
+						}, true)
+						return true
+					}),
+				).Return(nil)
+				client.On(
+					"ListObjectsV2Pages",
+					&s3.ListObjectsV2Input{
+						Bucket: awssdk.String("bucket-name"),
+						Prefix: awssdk.String("env:/prod/network/terraform.tfstate"),
+					},
+					mock.MatchedBy(func(callback func(res *s3.ListObjectsV2Output, lastPage bool) bool) bool {
+						callback(&s3.ListObjectsV2Output{
+							Contents: []*s3.Object{
+								{
+									Key:  awssdk.String("env:/prod/network/terraform.tfstate"),
+									Size: awssdk.Int64(5),
+								},
+							},
+						}, true)
+						return true
+					}),
+				).Return(nil)
+			},
+			want: []string{
+				"bucket-name/network/terraform.tfstate",
+				"bucket-name/env:/prod/network/terraform.tfstate",
+			},
+		},
+		{
+			name: "test every workspace with custom key prefix",
+			config: config.SupplierConfig{
+				Path:               "bucket-name/network/terraform.tfstate",
+				Workspaces:         []string{"*"},
+				WorkspaceKeyPrefix: "workspaces",
+			},
+			mocks: func(client *mocks.FakeS3) {
+				client.On(
+					"ListObjectsV2Pages",
+					&s3.ListObjectsV2Input{
+						Bucket: awssdk.String("bucket-name"),
+						Prefix: awssdk.String("network/terraform.tfstate"),
+					},
+					mock.MatchedBy(func(callback func(res *s3.ListObjectsV2Output, lastPage bool) bool) bool {
+						callback(&s3.ListObjectsV2Output{}, true)
+						return true
+					}),
+				).Return(nil)
+				client.On(
+					"ListObjectsV2Pages",
+					&s3.ListObjectsV2Input{
+						Bucket: awssdk.String("bucket-name"),
+						Prefix: awssdk.String("workspaces/"),
+					},
+					mock.MatchedBy(func(callback func(res *s3.ListObjectsV2Output, lastPage bool) bool) bool {
+						callback(&s3.ListObjectsV2Output{
+							Contents: []*s3.Object{
+								{
+									Key:  awssdk.String("workspaces/prod/network/terraform.tfstate"),
+									Size: awssdk.Int64(5),
+								},
+								{
+									Key:  awssdk.String("workspaces/prod/compute/terraform.tfstate"),
+									Size: awssdk.Int64(5),
+								},
+								{
+									Key:  awssdk.String("workspaces/staging/network/terraform.tfstate"),
+									Size: awssdk.Int64(5),
+								},
+							},
+						}, true)
+						return true
+					}),
+				).Return(nil)
+			},
+			want: []string{
+				"bucket-name/workspaces/prod/network/terraform.tfstate",
+				"bucket-name/workspaces/staging/network/terraform.tfstate",
+			},
+		},
 		{
 			name: "test when invalid config used",
 			config: config.SupplierConfig{
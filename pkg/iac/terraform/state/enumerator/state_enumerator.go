@@ -10,13 +10,23 @@ type StateEnumerator interface {
 	Enumerate() ([]string, error)
 }
 
-func GetEnumerator(config config.SupplierConfig) StateEnumerator {
+func GetEnumerator(config config.SupplierConfig, opts *backend.Options) StateEnumerator {
 
 	switch config.Backend {
 	case backend.BackendKeyFile:
 		return NewFileEnumerator(config)
 	case backend.BackendKeyS3:
 		return NewS3Enumerator(config)
+	case backend.BackendKeyGS:
+		return NewGSEnumerator(config, opts)
+	case backend.BackendKeyAzureRM:
+		return NewAzureRMEnumerator(config)
+	case backend.BackendKeyTFCloud:
+		return NewTFCloudEnumerator(config, backend.TFCloudOptions(config, opts))
+	case backend.BackendKeyConsul:
+		return NewConsulEnumerator(config)
+	case backend.BackendKeyHTTP, backend.BackendKeyHTTPS:
+		return NewHTTPEnumerator(config, opts)
 	}
 
 	logrus.WithFields(logrus.Fields{
@@ -0,0 +1,73 @@
+package enumerator
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/pkg/errors"
+)
+
+type TFCloudEnumerator struct {
+	config config.SupplierConfig
+	client *backend.TFCloudClient
+}
+
+func NewTFCloudEnumerator(config config.SupplierConfig, opts *backend.Options) *TFCloudEnumerator {
+	return &TFCloudEnumerator{
+		config,
+		backend.NewTFCloudClient(opts),
+	}
+}
+
+// Enumerate supports paths like ORGANIZATION/WORKSPACE, ORGANIZATION (every workspace),
+// ORGANIZATION/PREFIX* and ORGANIZATION?tags=TAG1,TAG2
+func (s *TFCloudEnumerator) Enumerate() ([]string, error) {
+	workspacePath, rawQuery := s.config.Path, ""
+	if i := strings.Index(workspacePath, "?"); i >= 0 {
+		workspacePath, rawQuery = workspacePath[:i], workspacePath[i+1:]
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to parse tfcloud path: %s", s.config.Path)
+	}
+
+	orgWorkspace := strings.SplitN(workspacePath, "/", 2)
+	organization := orgWorkspace[0]
+	if organization == "" {
+		return nil, errors.Errorf("Unable to parse tfcloud path: %s. Must be ORGANIZATION[/WORKSPACE][?tags=TAG1,TAG2]", s.config.Path)
+	}
+	pattern := ""
+	if len(orgWorkspace) == 2 {
+		pattern = orgWorkspace[1]
+	}
+	var tags []string
+	if queryTags := query.Get("tags"); queryTags != "" {
+		tags = strings.Split(queryTags, ",")
+	}
+
+	if pattern != "" && !strings.Contains(pattern, "*") && len(tags) == 0 {
+		return []string{workspacePath}, nil
+	}
+
+	// Name search is fuzzy, returned workspaces are matched against the pattern afterwards
+	nameSearch := strings.Split(pattern, "*")[0]
+	workspaces, err := s.client.ListWorkspaces(organization, nameSearch, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		if pattern != "" {
+			if match, _ := path.Match(pattern, workspace.Name); !match {
+				continue
+			}
+		}
+		keys = append(keys, strings.Join([]string{organization, workspace.Name}, "/"))
+	}
+
+	return keys, nil
+}
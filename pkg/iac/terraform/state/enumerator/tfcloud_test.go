@@ -0,0 +1,106 @@
+package enumerator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+)
+
+func TestTFCloudEnumerator_Enumerate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config config.SupplierConfig
+		want   []string
+		err    string
+	}{
+		{
+			name: "test single workspace",
+			config: config.SupplierConfig{
+				Path: "my-org/my-workspace",
+			},
+			want: []string{
+				"my-org/my-workspace",
+			},
+		},
+		{
+			name: "test every workspace of organization",
+			config: config.SupplierConfig{
+				Path: "my-org",
+			},
+			want: []string{
+				"my-org/prod-network",
+				"my-org/prod-dns",
+				"my-org/staging-dns",
+			},
+		},
+		{
+			name: "test workspace prefix",
+			config: config.SupplierConfig{
+				Path: "my-org/prod-*",
+			},
+			want: []string{
+				"my-org/prod-network",
+				"my-org/prod-dns",
+			},
+		},
+		{
+			name: "test workspace tags",
+			config: config.SupplierConfig{
+				Path: "my-org?tags=dns",
+			},
+			want: []string{
+				"my-org/prod-dns",
+				"my-org/staging-dns",
+			},
+		},
+		{
+			name: "test invalid path",
+			config: config.SupplierConfig{
+				Path: "/my-workspace",
+			},
+			err: "Unable to parse tfcloud path: /my-workspace. Must be ORGANIZATION[/WORKSPACE][?tags=TAG1,TAG2]",
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/organizations/my-org/workspaces" || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query := r.URL.Query()
+		if query.Get("search[tags]") == "dns" {
+			fmt.Fprint(w, `{"data": [{"attributes": {"name": "prod-dns"}}, {"attributes": {"name": "staging-dns"}}], "meta": {"pagination": {"next-page": null}}}`)
+			return
+		}
+		if query.Get("page[number]") == "1" {
+			fmt.Fprint(w, `{"data": [{"attributes": {"name": "prod-network"}}, {"attributes": {"name": "prod-dns"}}], "meta": {"pagination": {"next-page": 2}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"data": [{"attributes": {"name": "staging-dns"}}], "meta": {"pagination": {"next-page": null}}}`)
+	}))
+	defer server.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewTFCloudEnumerator(tt.config, &backend.Options{
+				TFCloudToken:    "token",
+				TFCloudEndpoint: fmt.Sprintf("%s/api/v2", server.URL),
+			})
+			got, err := s.Enumerate()
+			if err != nil && err.Error() != tt.err {
+				t.Fatalf("Expected error '%s', got '%s'", tt.err, err.Error())
+			}
+			if err == nil && tt.err != "" {
+				t.Fatalf("Expected error '%s' but got nil", tt.err)
+			}
+			if tt.err == "" && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Enumerate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
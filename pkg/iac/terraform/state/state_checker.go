@@ -0,0 +1,159 @@
+package state
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/terraform/states/statefile"
+	"github.com/sirupsen/logrus"
+
+	"github.com/cloudskiff/driftctl/pkg/alerter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+)
+
+// DuplicatedStateAlert is raised when two sources are copies of the same state
+type DuplicatedStateAlert struct {
+	sources []StateMetadata
+}
+
+func NewDuplicatedStateAlert(first, second StateMetadata) *DuplicatedStateAlert {
+	sources := []StateMetadata{first, second}
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].Source < sources[j].Source
+	})
+	return &DuplicatedStateAlert{sources}
+}
+
+func (d *DuplicatedStateAlert) Message() string {
+	return fmt.Sprintf(
+		"IaC sources %s (serial %d) and %s (serial %d) share lineage %s, they are copies of the same state and one of them may be stale",
+		d.sources[0].Source,
+		d.sources[0].Serial,
+		d.sources[1].Source,
+		d.sources[1].Serial,
+		d.sources[0].Lineage,
+	)
+}
+
+func (d *DuplicatedStateAlert) ShouldIgnoreResource() bool {
+	return false
+}
+
+// LockedStateAlert is raised when a state is locked, terraform is then changing resources it manages
+type LockedStateAlert struct {
+	source string
+	lock   backend.LockInfo
+}
+
+func NewLockedStateAlert(source string, lock backend.LockInfo) *LockedStateAlert {
+	return &LockedStateAlert{source, lock}
+}
+
+func (l *LockedStateAlert) Message() string {
+	return fmt.Sprintf(
+		"IaC source %s is locked by %s for %s since %s, an apply may be in progress and reported drifts may be transient",
+		l.source,
+		l.lock.Who,
+		l.lock.Operation,
+		l.lock.Created.Format("2006-01-02 15:04:05 MST"),
+	)
+}
+
+func (l *LockedStateAlert) ShouldIgnoreResource() bool {
+	return false
+}
+
+// StateMetadata identifies a state independently of where it is stored
+type StateMetadata struct {
+	Source           string
+	Lineage          string
+	Serial           uint64
+	TerraformVersion string
+}
+
+type lockReader interface {
+	ReadLock(path string) (*backend.LockInfo, error)
+}
+
+// StateChecker looks for states that should not be trusted as is, it is shared by every state reader
+// so that copies of a state read from different sources are detected
+type StateChecker struct {
+	alerter    alerter.AlerterInterface
+	lockReader lockReader
+	lock       sync.Mutex
+	lineages   map[string]StateMetadata
+}
+
+func NewStateChecker(alerter alerter.AlerterInterface, opts *backend.Options) *StateChecker {
+	checker := &StateChecker{
+		alerter:  alerter,
+		lineages: make(map[string]StateMetadata),
+	}
+	if opts != nil && opts.LockTable != "" {
+		checker.lockReader = backend.NewS3LockReader(opts.LockTable)
+	}
+	return checker
+}
+
+// Check alerts when a state shares its lineage with a state already read, or when it is locked
+func (c *StateChecker) Check(config config.SupplierConfig, file *statefile.File) {
+	metadata := StateMetadata{
+		Source:  sourceName(config),
+		Lineage: file.Lineage,
+		Serial:  file.Serial,
+	}
+	if file.TerraformVersion != nil {
+		metadata.TerraformVersion = file.TerraformVersion.String()
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"source":            metadata.Source,
+		"lineage":           metadata.Lineage,
+		"serial":            metadata.Serial,
+		"terraform_version": metadata.TerraformVersion,
+	}).Debug("Read state metadata")
+
+	c.checkLineage(metadata)
+
+	if config.Backend == backend.BackendKeyS3 {
+		c.checkLock(metadata.Source, config.Path)
+	}
+}
+
+func (c *StateChecker) checkLineage(metadata StateMetadata) {
+	// States written by old terraform versions may not have any lineage
+	if metadata.Lineage == "" {
+		return
+	}
+
+	c.lock.Lock()
+	other, exists := c.lineages[metadata.Lineage]
+	if !exists {
+		c.lineages[metadata.Lineage] = metadata
+	}
+	c.lock.Unlock()
+
+	if exists && other.Source != metadata.Source {
+		c.alerter.SendAlert(metadata.Source, NewDuplicatedStateAlert(other, metadata))
+	}
+}
+
+func (c *StateChecker) checkLock(source, path string) {
+	if c.lockReader == nil {
+		return
+	}
+
+	lock, err := c.lockReader.ReadLock(path)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"source": source,
+			"err":    err.Error(),
+		}).Warn("Unable to check whether state is locked")
+		return
+	}
+	if lock != nil {
+		c.alerter.SendAlert(source, NewLockedStateAlert(source, *lock))
+	}
+}
@@ -0,0 +1,107 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/states/statefile"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/alerter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+)
+
+type lockReaderFunc func(path string) (*backend.LockInfo, error)
+
+func (f lockReaderFunc) ReadLock(path string) (*backend.LockInfo, error) {
+	return f(path)
+}
+
+func TestStateChecker_Check(t *testing.T) {
+	type state struct {
+		config config.SupplierConfig
+		file   *statefile.File
+	}
+	tests := []struct {
+		name       string
+		states     []state
+		wantAlerts map[string][]string
+	}{
+		{
+			name: "test states with different lineages",
+			states: []state{
+				{config.SupplierConfig{Path: "a.tfstate"}, &statefile.File{Lineage: "lineage-a", Serial: 1}},
+				{config.SupplierConfig{Path: "b.tfstate"}, &statefile.File{Lineage: "lineage-b", Serial: 1}},
+				{config.SupplierConfig{Path: "c.tfstate"}, &statefile.File{Serial: 1}},
+				{config.SupplierConfig{Path: "d.tfstate"}, &statefile.File{Serial: 1}},
+			},
+			wantAlerts: map[string][]string{},
+		},
+		{
+			name: "test states sharing a lineage",
+			states: []state{
+				{config.SupplierConfig{Backend: backend.BackendKeyS3, Path: "bucket/terraform.tfstate"}, &statefile.File{Lineage: "lineage-a", Serial: 12}},
+				{config.SupplierConfig{Path: "terraform.tfstate"}, &statefile.File{Lineage: "lineage-a", Serial: 9}},
+			},
+			wantAlerts: map[string][]string{
+				"tfstate://terraform.tfstate": {
+					"IaC sources tfstate+s3://bucket/terraform.tfstate (serial 12) and tfstate://terraform.tfstate (serial 9) share lineage lineage-a, they are copies of the same state and one of them may be stale",
+				},
+			},
+		},
+		{
+			name: "test same state read twice",
+			states: []state{
+				{config.SupplierConfig{Path: "terraform.tfstate"}, &statefile.File{Lineage: "lineage-a", Serial: 1}},
+				{config.SupplierConfig{Path: "terraform.tfstate"}, &statefile.File{Lineage: "lineage-a", Serial: 1}},
+			},
+			wantAlerts: map[string][]string{},
+		},
+		{
+			name: "test locked s3 state",
+			states: []state{
+				{config.SupplierConfig{Backend: backend.BackendKeyS3, Path: "bucket/locked.tfstate"}, &statefile.File{Lineage: "lineage-a", Serial: 1}},
+				{config.SupplierConfig{Backend: backend.BackendKeyS3, Path: "bucket/unlocked.tfstate"}, &statefile.File{Lineage: "lineage-b", Serial: 1}},
+				{config.SupplierConfig{Backend: backend.BackendKeyS3, Path: "bucket/unreadable.tfstate"}, &statefile.File{Lineage: "lineage-c", Serial: 1}},
+				{config.SupplierConfig{Path: "bucket/locked.tfstate"}, &statefile.File{Lineage: "lineage-d", Serial: 1}},
+			},
+			wantAlerts: map[string][]string{
+				"tfstate+s3://bucket/locked.tfstate": {
+					"IaC source tfstate+s3://bucket/locked.tfstate is locked by ci@runner for OperationTypeApply since 2021-03-02 10:04:05 UTC, an apply may be in progress and reported drifts may be transient",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alr := alerter.NewAlerter()
+			checker := NewStateChecker(alr, &backend.Options{})
+			checker.lockReader = lockReaderFunc(func(path string) (*backend.LockInfo, error) {
+				switch path {
+				case "bucket/locked.tfstate":
+					return &backend.LockInfo{
+						Who:       "ci@runner",
+						Operation: "OperationTypeApply",
+						Created:   time.Date(2021, 3, 2, 10, 4, 5, 0, time.UTC),
+					}, nil
+				case "bucket/unreadable.tfstate":
+					return nil, assert.AnError
+				}
+				return nil, nil
+			})
+
+			for _, state := range tt.states {
+				checker.Check(state.config, state.file)
+			}
+
+			got := make(map[string][]string)
+			for key, alerts := range alr.Retrieve() {
+				for _, alert := range alerts {
+					got[key] = append(got[key], alert.Message())
+				}
+			}
+			assert.Equal(t, tt.wantAlerts, got)
+		})
+	}
+}
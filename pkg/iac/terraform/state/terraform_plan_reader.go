@@ -0,0 +1,282 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/cloudskiff/driftctl/pkg/terraform"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+const TerraformPlanReaderSupplier = "tfplan"
+
+type plan struct {
+	FormatVersion   string               `json:"format_version"`
+	PlannedValues   *planValues          `json:"planned_values"`
+	ResourceChanges []planResourceChange `json:"resource_changes"`
+}
+
+type planValues struct {
+	RootModule planModule `json:"root_module"`
+}
+
+type planModule struct {
+	Address      string         `json:"address"`
+	Resources    []planResource `json:"resources"`
+	ChildModules []planModule   `json:"child_modules"`
+}
+
+type planResource struct {
+	Address      string                 `json:"address"`
+	Mode         string                 `json:"mode"`
+	Type         string                 `json:"type"`
+	ProviderName string                 `json:"provider_name"`
+	Values       map[string]interface{} `json:"values"`
+}
+
+type planResourceChange struct {
+	Address      string `json:"address"`
+	Mode         string `json:"mode"`
+	Type         string `json:"type"`
+	ProviderName string `json:"provider_name"`
+	Change       struct {
+		Actions      []string               `json:"actions"`
+		After        map[string]interface{} `json:"after"`
+		AfterUnknown map[string]interface{} `json:"after_unknown"`
+	} `json:"change"`
+}
+
+// TerraformPlanReader reads resources as they will be once a plan is applied,
+// from the output of terraform show -json
+type TerraformPlanReader struct {
+	TerraformStateReader
+	// unknownAttributes are paths of attributes unknown until apply, by resource
+	unknownAttributes map[string][][]string
+}
+
+func NewPlanReader(config config.SupplierConfig, library *terraform.ProviderLibrary, backendOpts *backend.Options, typeFilter filter.TypeFilter) (*TerraformPlanReader, error) {
+	return &TerraformPlanReader{
+		TerraformStateReader{library: library, config: config, deserializers: iac.Deserializers(), backendOptions: backendOpts, typeFilter: typeFilter},
+		map[string][][]string{},
+	}, nil
+}
+
+func (r *TerraformPlanReader) Resources() ([]resource.Resource, error) {
+	logrus.WithFields(logrus.Fields{
+		"path": r.config.Path,
+	}).Debug("Reading resources from plan")
+	values, err := r.retrievePlan()
+	if err != nil {
+		return nil, err
+	}
+	return r.decode(r.config, values)
+}
+
+func (r *TerraformPlanReader) retrievePlan() (map[string][]cty.Value, error) {
+	b, err := backend.GetBackend(r.config, r.backendOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	planReader, err := backend.NewDecodingReader(b, r.backendOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	p := plan{}
+	if err := json.NewDecoder(planReader).Decode(&p); err != nil {
+		return nil, errors.Wrapf(err, "Unable to read plan %s", r.config.Path)
+	}
+	if p.FormatVersion == "" {
+		return nil, errors.Errorf("Unable to read plan %s, it must be the JSON output of terraform show -json", r.config.Path)
+	}
+
+	unknownAttributes := plannedUnknownAttributes(&p)
+	resMap := make(map[string][]cty.Value)
+	for _, res := range plannedResources(&p) {
+		if res.Mode != "managed" {
+			logrus.WithFields(logrus.Fields{
+				"mode":    res.Mode,
+				"address": res.Address,
+			}).Debug("Skipping plan entry as it is not a managed resource")
+			continue
+		}
+
+		providerType := res.ProviderName[strings.LastIndex(res.ProviderName, "/")+1:]
+		provider := r.library.Provider(providerType)
+		if provider == nil {
+			logrus.WithFields(logrus.Fields{
+				"providerKey": providerType,
+			}).Debug("Unsupported provider found in plan")
+			continue
+		}
+
+		// Resources are matched by id, which is unknown until apply for resources to be created
+		if id, _ := res.Values["id"].(string); id == "" {
+			logrus.WithFields(logrus.Fields{
+				"address": res.Address,
+			}).Debug("Skipping plan entry as its id is unknown until apply")
+			continue
+		}
+
+		schema, exists := provider.Schema()[res.Type]
+		if !exists {
+			logrus.WithFields(logrus.Fields{
+				"type": res.Type,
+			}).Debug("Unsupported resource type found in plan")
+			continue
+		}
+
+		val, err := decodePlannedValues(res.Values, schema.Block.ImpliedType())
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"address": res.Address,
+			}).Error("Unable to decode resource from plan")
+			return nil, err
+		}
+
+		resMap[res.Type] = append(resMap[res.Type], val)
+		if unknown := unknownAttributes[res.Address]; len(unknown) > 0 {
+			id, _ := res.Values["id"].(string)
+			r.unknownAttributes[fmt.Sprintf("%s.%s", res.Type, id)] = unknown
+		}
+	}
+
+	return resMap, nil
+}
+
+// IsFieldIgnored tells whether a field of a resource read from the plan is unknown until apply,
+// it must not be compared with the remote resource as it is decoded as null
+func (r *TerraformPlanReader) IsFieldIgnored(res resource.Resource, path []string) bool {
+	unknownPaths, exists := r.unknownAttributes[resource.Key(res)]
+	if !exists {
+		return false
+	}
+	attributes := attributePath(reflect.TypeOf(res), path)
+	for _, unknown := range unknownPaths {
+		if len(unknown) <= len(attributes) && reflect.DeepEqual(unknown, attributes[:len(unknown)]) {
+			return true
+		}
+	}
+	return false
+}
+
+// plannedUnknownAttributes returns paths of attributes unknown until apply by resource address,
+// from the after_unknown value of resource changes
+func plannedUnknownAttributes(p *plan) map[string][][]string {
+	unknownAttributes := make(map[string][][]string)
+	for _, change := range p.ResourceChanges {
+		paths := unknownPaths(change.Change.AfterUnknown, []string{})
+		if len(paths) > 0 {
+			unknownAttributes[change.Address] = paths
+		}
+	}
+	return unknownAttributes
+}
+
+// unknownPaths walks an after_unknown value, where unknown attributes are set to true
+func unknownPaths(value interface{}, path []string) [][]string {
+	paths := make([][]string, 0)
+	switch v := value.(type) {
+	case bool:
+		if v {
+			paths = append(paths, append([]string{}, path...))
+		}
+	case map[string]interface{}:
+		for key, child := range v {
+			paths = append(paths, unknownPaths(child, append(path, key))...)
+		}
+	case []interface{}:
+		for i, child := range v {
+			paths = append(paths, unknownPaths(child, append(path, strconv.Itoa(i)))...)
+		}
+	}
+	return paths
+}
+
+// attributePath converts a path of resource struct fields, as found in analysis changes,
+// to the path of the matching terraform attribute
+func attributePath(ty reflect.Type, path []string) []string {
+	attributes := make([]string, 0, len(path))
+	for _, name := range path {
+		for ty.Kind() == reflect.Ptr {
+			ty = ty.Elem()
+		}
+		switch ty.Kind() {
+		case reflect.Struct:
+			field, exists := ty.FieldByName(name)
+			if !exists {
+				return attributes
+			}
+			attributes = append(attributes, field.Tag.Get("cty"))
+			ty = field.Type
+		case reflect.Slice, reflect.Map:
+			attributes = append(attributes, name)
+			ty = ty.Elem()
+		default:
+			return attributes
+		}
+	}
+	return attributes
+}
+
+// plannedResources returns resources as they will be after apply.
+// Planned values are used when available, otherwise changes are applied to get the same result
+func plannedResources(p *plan) []planResource {
+	resources := make([]planResource, 0)
+	if p.PlannedValues != nil {
+		modules := []planModule{p.PlannedValues.RootModule}
+		for len(modules) > 0 {
+			module := modules[0]
+			modules = append(modules[1:], module.ChildModules...)
+			resources = append(resources, module.Resources...)
+		}
+		return resources
+	}
+
+	for _, change := range p.ResourceChanges {
+		// Change after value is null when the resource is destroyed
+		if change.Change.After == nil {
+			continue
+		}
+		resources = append(resources, planResource{
+			Address:      change.Address,
+			Mode:         change.Mode,
+			Type:         change.Type,
+			ProviderName: change.ProviderName,
+			Values:       change.Change.After,
+		})
+	}
+	return resources
+}
+
+// decodePlannedValues converts resource values to the provider schema type.
+// Values unknown until apply are missing from the plan, they are decoded as null and ignored by IsFieldIgnored
+func decodePlannedValues(values map[string]interface{}, ty cty.Type) (cty.Value, error) {
+	// Ignore attributes unknown to the provider schema, the plan may have been
+	// generated with a superior version of the provider
+	for name := range values {
+		if !ty.HasAttribute(name) {
+			delete(values, name)
+		}
+	}
+
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	return ctyjson.Unmarshal(raw, ty)
+}
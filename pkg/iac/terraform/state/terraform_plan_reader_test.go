@@ -0,0 +1,134 @@
+package state
+
+import (
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	resourceaws "github.com/cloudskiff/driftctl/pkg/resource/aws"
+	"github.com/cloudskiff/driftctl/pkg/terraform"
+	"github.com/cloudskiff/driftctl/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerraformPlanReader_Resources(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want []resource.Resource
+		err  string
+	}{
+		{
+			name: "planned values",
+			path: "testdata/plan/planned_values.json",
+			want: []resource.Resource{
+				&resourceaws.AwsEbsVolume{
+					Id:                 "vol-01ddc91d3d9d1318b",
+					Arn:                awssdk.String("arn:aws:ec2:eu-west-3:047081014315:volume/vol-01ddc91d3d9d1318b"),
+					AvailabilityZone:   awssdk.String("eu-west-3a"),
+					Encrypted:          awssdk.Bool(false),
+					Iops:               awssdk.Int(100),
+					KmsKeyId:           awssdk.String(""),
+					MultiAttachEnabled: awssdk.Bool(false),
+					OutpostArn:         awssdk.String(""),
+					Size:               awssdk.Int(16),
+					SnapshotId:         awssdk.String(""),
+					Tags:               map[string]string{"Name": "HelloWorld"},
+					Type:               awssdk.String("gp2"),
+				},
+				&resourceaws.AwsEbsVolume{
+					Id:               "vol-0123456789abcdef0",
+					AvailabilityZone: awssdk.String("eu-west-3b"),
+					Size:             awssdk.Int(100),
+				},
+			},
+		},
+		{
+			name: "resource changes",
+			path: "testdata/plan/resource_changes.json",
+			want: []resource.Resource{
+				&resourceaws.AwsEbsVolume{
+					Id:               "vol-01ddc91d3d9d1318b",
+					AvailabilityZone: awssdk.String("eu-west-3a"),
+					Size:             awssdk.Int(8),
+				},
+			},
+		},
+		{
+			name: "not a plan",
+			path: "testdata/v4/valid.tfstate",
+			err:  "Unable to read plan testdata/v4/valid.tfstate, it must be the JSON output of terraform show -json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			library := terraform.NewProviderLibrary()
+			library.AddProvider(terraform.AWS, mocks.NewMockedGoldenTFProvider("ec2_ebs_volume", nil, false))
+
+			r, err := NewPlanReader(config.SupplierConfig{Path: tt.path}, library, &backend.Options{}, nil)
+			assert.Nil(t, err)
+
+			got, err := r.Resources()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Len(t, got, len(tt.want))
+			for i, res := range got {
+				ebsVolume := res.(*resourceaws.AwsEbsVolume)
+				ebsVolume.CtyVal = nil
+				assert.Equal(t, tt.want[i], ebsVolume)
+			}
+		})
+	}
+}
+
+func TestTerraformPlanReader_IsFieldIgnored(t *testing.T) {
+	library := terraform.NewProviderLibrary()
+	library.AddProvider(terraform.AWS, mocks.NewMockedGoldenTFProvider("ec2_ebs_volume", nil, false))
+
+	r, err := NewPlanReader(config.SupplierConfig{Path: "testdata/plan/planned_values.json"}, library, &backend.Options{}, nil)
+	assert.Nil(t, err)
+	_, err = r.Resources()
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name string
+		res  resource.Resource
+		path []string
+		want bool
+	}{
+		{
+			name: "unknown attribute",
+			res:  &resourceaws.AwsEbsVolume{Id: "vol-0123456789abcdef0"},
+			path: []string{"Arn"},
+			want: true,
+		},
+		{
+			name: "known attribute",
+			res:  &resourceaws.AwsEbsVolume{Id: "vol-0123456789abcdef0"},
+			path: []string{"Size"},
+			want: false,
+		},
+		{
+			name: "known map attribute",
+			res:  &resourceaws.AwsEbsVolume{Id: "vol-0123456789abcdef0"},
+			path: []string{"Tags", "Name"},
+			want: false,
+		},
+		{
+			name: "resource without unknown attributes",
+			res:  &resourceaws.AwsEbsVolume{Id: "vol-01ddc91d3d9d1318b"},
+			path: []string{"Arn"},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, r.IsFieldIgnored(tt.res, tt.path))
+		})
+	}
+}
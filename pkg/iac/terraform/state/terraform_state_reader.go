@@ -1,18 +1,26 @@
 package state
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 
+	"github.com/cloudskiff/driftctl/pkg/alerter"
+	"github.com/cloudskiff/driftctl/pkg/filter"
 	"github.com/cloudskiff/driftctl/pkg/iac"
 	"github.com/cloudskiff/driftctl/pkg/iac/config"
 	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
 	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/enumerator"
+	"github.com/cloudskiff/driftctl/pkg/parallel"
 	"github.com/cloudskiff/driftctl/pkg/remote/deserializer"
 	"github.com/cloudskiff/driftctl/pkg/resource"
 	"github.com/cloudskiff/driftctl/pkg/terraform"
 	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/configs/hcl2shim"
 	"github.com/hashicorp/terraform/states"
 	"github.com/hashicorp/terraform/states/statefile"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 	ctyconvert "github.com/zclconf/go-cty/cty/convert"
@@ -21,22 +29,46 @@ import (
 
 const TerraformStateReaderSupplier = "tfstate"
 
+// SkippedStateAlert is raised when an IaC source or a state cannot be read and the scan continues without it
+type SkippedStateAlert struct {
+	source string
+	err    error
+}
+
+func NewSkippedStateAlert(source string, err error) *SkippedStateAlert {
+	return &SkippedStateAlert{source, err}
+}
+
+func (s *SkippedStateAlert) Message() string {
+	return fmt.Sprintf("Skipped IaC source %s, resources it manages may be reported as not covered by IaC: %s", s.source, s.err)
+}
+
+func (s *SkippedStateAlert) ShouldIgnoreResource() bool {
+	return false
+}
+
+func (s *SkippedStateAlert) Source() string {
+	return s.source
+}
+
 type TerraformStateReader struct {
 	library        *terraform.ProviderLibrary
+	alerter        alerter.AlerterInterface
+	checker        *StateChecker
 	config         config.SupplierConfig
-	backend        backend.Backend
 	enumerator     enumerator.StateEnumerator
 	deserializers  []deserializer.CTYDeserializer
 	backendOptions *backend.Options
+	typeFilter     filter.TypeFilter
 }
 
 func (r *TerraformStateReader) initReader() error {
-	r.enumerator = enumerator.GetEnumerator(r.config)
+	r.enumerator = enumerator.GetEnumerator(r.config, r.backendOptions)
 	return nil
 }
 
-func NewReader(config config.SupplierConfig, library *terraform.ProviderLibrary, backendOpts *backend.Options) (*TerraformStateReader, error) {
-	reader := TerraformStateReader{library: library, config: config, deserializers: iac.Deserializers(), backendOptions: backendOpts}
+func NewReader(config config.SupplierConfig, library *terraform.ProviderLibrary, backendOpts *backend.Options, typeFilter filter.TypeFilter, alerter alerter.AlerterInterface, checker *StateChecker) (*TerraformStateReader, error) {
+	reader := TerraformStateReader{library: library, alerter: alerter, checker: checker, config: config, deserializers: iac.Deserializers(), backendOptions: backendOpts, typeFilter: typeFilter}
 	err := reader.initReader()
 	if err != nil {
 		return nil, err
@@ -44,20 +76,31 @@ func NewReader(config config.SupplierConfig, library *terraform.ProviderLibrary,
 	return &reader, nil
 }
 
-func (r *TerraformStateReader) retrieve() (map[string][]cty.Value, error) {
-	b, err := backend.GetBackend(r.config, r.backendOptions)
+// retrieve returns values of managed resources by type, and resources referenced by data sources
+// when they are read
+func (r *TerraformStateReader) retrieve(config config.SupplierConfig) (map[string][]cty.Value, []resource.Resource, error) {
+	b, err := backend.GetBackend(config, r.backendOptions)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	r.backend = b
 
-	state, err := read(r.backend)
-	defer r.backend.Close()
+	defer b.Close()
+	stateReader, err := backend.NewDecodingReader(b, r.backendOptions)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	file, err := read(stateReader)
+	if err != nil {
+		return nil, nil, err
+	}
+	if r.checker != nil {
+		r.checker.Check(config, file)
+	}
+	state := file.State
+
 	resMap := make(map[string][]cty.Value)
+	referenced := make([]resource.Resource, 0)
 	for moduleName, module := range state.Modules {
 		logrus.WithFields(logrus.Fields{
 			"module":        moduleName,
@@ -66,6 +109,10 @@ func (r *TerraformStateReader) retrieve() (map[string][]cty.Value, error) {
 		for _, stateRes := range module.Resources {
 			resName := stateRes.Addr.Resource.Name
 			resType := stateRes.Addr.Resource.Type
+			if stateRes.Addr.Resource.Mode == addrs.DataResourceMode && r.readDataSources() {
+				referenced = append(referenced, r.referencedResources(stateRes)...)
+				continue
+			}
 			if stateRes.Addr.Resource.Mode != addrs.ManagedResourceMode {
 				logrus.WithFields(logrus.Fields{
 					"mode": stateRes.Addr.Resource.Mode,
@@ -99,6 +146,16 @@ func (r *TerraformStateReader) retrieve() (map[string][]cty.Value, error) {
 						}).Debug("Got a cty path error when deserializing state")
 
 						decodedVal, err = r.convertInstance(instance.Current, schema.Block.ImpliedType())
+					} else if instance.Current.AttrsFlat != nil {
+						// States written by terraform 0.11 and older are upgraded in memory by terraform
+						// but keep flatmap attributes, that may not match the current provider schema
+						logrus.WithFields(logrus.Fields{
+							"name": resName,
+							"type": resType,
+							"err":  err.Error(),
+						}).Debug("Got an error when deserializing legacy state")
+
+						decodedVal, err = r.convertLegacyInstance(instance.Current, schema.Block.ImpliedType())
 					}
 
 					if err != nil {
@@ -106,7 +163,7 @@ func (r *TerraformStateReader) retrieve() (map[string][]cty.Value, error) {
 							"name": resName,
 							"type": resType,
 						}).Error("Unable to decode resource from state")
-						return nil, err
+						return nil, nil, err
 					}
 				}
 				_, exists := resMap[stateRes.Addr.Resource.Type]
@@ -121,7 +178,57 @@ func (r *TerraformStateReader) retrieve() (map[string][]cty.Value, error) {
 		}
 	}
 
-	return resMap, nil
+	return resMap, referenced, nil
+}
+
+// referencedResources returns resources read by a data source, they exist but are managed outside of this state.
+// Only data sources reading a resource type known by the provider are considered, others such as
+// aws_iam_policy_document do not reference any existing resource.
+func (r *TerraformStateReader) referencedResources(stateRes *states.Resource) []resource.Resource {
+	resType := stateRes.Addr.Resource.Type
+	provider := r.library.Provider(stateRes.ProviderConfig.Provider.Type)
+	if provider == nil {
+		return nil
+	}
+	if _, exists := provider.Schema()[resType]; !exists {
+		return nil
+	}
+	if r.typeFilter != nil && r.typeFilter.IsTypeIgnored(resType) {
+		return nil
+	}
+
+	results := make([]resource.Resource, 0, len(stateRes.Instances))
+	for _, instance := range stateRes.Instances {
+		if instance.Current == nil {
+			continue
+		}
+		id := instanceId(instance.Current)
+		if id == "" {
+			continue
+		}
+		logrus.WithFields(logrus.Fields{
+			"name": stateRes.Addr.Resource.Name,
+			"type": resType,
+			"id":   id,
+		}).Debug("Found resource referenced by data source")
+		results = append(results, resource.ReferencedResource{
+			SerializedResource: resource.SerializedResource{Id: id, Type: resType},
+		})
+	}
+	return results
+}
+
+func instanceId(instance *states.ResourceInstanceObjectSrc) string {
+	if instance.AttrsJSON == nil {
+		return instance.AttrsFlat["id"]
+	}
+	attrs := struct {
+		Id string `json:"id"`
+	}{}
+	if err := json.Unmarshal(instance.AttrsJSON, &attrs); err != nil {
+		return ""
+	}
+	return attrs.Id
 }
 
 func (r *TerraformStateReader) convertInstance(instance *states.ResourceInstanceObjectSrc, ty cty.Type) (*states.ResourceInstanceObject, error) {
@@ -152,7 +259,35 @@ func (r *TerraformStateReader) convertInstance(instance *states.ResourceInstance
 	return instanceObj, nil
 }
 
-func (r *TerraformStateReader) decode(values map[string][]cty.Value) ([]resource.Resource, error) {
+// convertLegacyInstance decodes flatmap attributes one by one, attributes that cannot be converted
+// to the current provider schema are ignored
+func (r *TerraformStateReader) convertLegacyInstance(instance *states.ResourceInstanceObjectSrc, ty cty.Type) (*states.ResourceInstanceObject, error) {
+	values := make(map[string]cty.Value, len(ty.AttributeTypes()))
+	for name, attrType := range ty.AttributeTypes() {
+		value, err := hcl2shim.HCL2ValueFromFlatmap(instance.AttrsFlat, cty.Object(map[string]cty.Type{name: attrType}))
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"attribute": name,
+				"err":       err.Error(),
+			}).Debug("Ignoring legacy state attribute")
+			values[name] = cty.NullVal(attrType)
+			continue
+		}
+		values[name] = value.GetAttr(name)
+	}
+
+	logrus.Debug("Successfully converted legacy resource")
+
+	return &states.ResourceInstanceObject{
+		Value:               cty.ObjectVal(values),
+		Status:              instance.Status,
+		Dependencies:        instance.Dependencies,
+		Private:             instance.Private,
+		CreateBeforeDestroy: instance.CreateBeforeDestroy,
+	}, nil
+}
+
+func (r *TerraformStateReader) decode(config config.SupplierConfig, values map[string][]cty.Value) ([]resource.Resource, error) {
 	results := make([]resource.Resource, 0)
 	for _, deserializer := range r.deserializers {
 
@@ -161,6 +296,12 @@ func (r *TerraformStateReader) decode(values map[string][]cty.Value) ([]resource
 		if !exists {
 			continue
 		}
+		if r.typeFilter != nil && r.typeFilter.IsTypeIgnored(typ) {
+			logrus.WithFields(logrus.Fields{
+				"type": typ,
+			}).Debug("Skipped decoding of ignored resource type")
+			continue
+		}
 		decodedResources, err := deserializer.Deserialize(vals)
 		if err != nil {
 			logrus.Warnf("Could not read from decoder for %s: %+v", typ, err)
@@ -168,8 +309,8 @@ func (r *TerraformStateReader) decode(values map[string][]cty.Value) ([]resource
 		}
 		for _, res := range decodedResources {
 			logrus.WithFields(logrus.Fields{
-				"path":    r.config.Path,
-				"backend": r.config.Backend,
+				"path":    config.Path,
+				"backend": config.Backend,
 				"id":      res.TerraformId(),
 				"type":    res.TerraformType(),
 			}).Debug("Found IAC resource")
@@ -197,58 +338,152 @@ func (r *TerraformStateReader) decode(values map[string][]cty.Value) ([]resource
 func (r *TerraformStateReader) Resources() ([]resource.Resource, error) {
 
 	if r.enumerator == nil {
-		return r.retrieveForState(r.config.Path)
+		resources, err := r.retrieveForState(r.config.Path)
+		if err != nil && r.continueOnError() {
+			r.skip(r.config.Path, err)
+			return []resource.Resource{}, nil
+		}
+		return resources, err
 	}
 
 	return r.retrieveMultiplesStates()
 }
 
 func (r *TerraformStateReader) retrieveForState(path string) ([]resource.Resource, error) {
-	r.config.Path = path
+	// States may be read concurrently, each one is read with its own copy of the configuration
+	config := r.config
+	config.Path = path
 	logrus.WithFields(logrus.Fields{
-		"path":    r.config.Path,
-		"backend": r.config.Backend,
+		"path":    config.Path,
+		"backend": config.Backend,
 	}).Debug("Reading resources from state")
-	values, err := r.retrieve()
+	values, referenced, err := r.retrieve(config)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := r.decode(config, values)
 	if err != nil {
 		return nil, err
 	}
-	return r.decode(values)
+	return append(resources, referenced...), nil
+}
+
+type stateResult struct {
+	key       string
+	resources []resource.Resource
+	err       error
 }
 
+// retrieveMultiplesStates reads enumerated states in parallel, reading stops at the first failing state.
+// When continuing on errors, every state is read and failing ones are skipped and reported as alerts.
 func (r *TerraformStateReader) retrieveMultiplesStates() ([]resource.Resource, error) {
 	keys, err := r.enumerator.Enumerate()
 	if err != nil {
+		if r.continueOnError() {
+			r.skip(r.config.Path, err)
+			return []resource.Resource{}, nil
+		}
 		return nil, err
 	}
 	logrus.WithFields(logrus.Fields{
 		"keys": keys,
 	}).Debug("Enumerated keys")
-	results := make([]resource.Resource, 0)
 
+	runner := parallel.NewParallelRunner(context.TODO(), r.parallelism())
 	for _, key := range keys {
-		resources, err := r.retrieveForState(key)
-		if err != nil {
-			return nil, err
+		key := key
+		runner.Run(func() (interface{}, error) {
+			resources, err := r.retrieveForState(key)
+			if err != nil && !r.continueOnError() {
+				return nil, errors.Wrapf(err, "Unable to read state %s", key)
+			}
+			return stateResult{key: key, resources: resources, err: err}, nil
+		})
+	}
+
+	resourcesByKey := make(map[string][]resource.Resource, len(keys))
+ReadLoop:
+	for {
+		select {
+		case res, ok := <-runner.Read():
+			if !ok {
+				break ReadLoop
+			}
+			result, isResult := res.(stateResult)
+			if !isResult {
+				continue
+			}
+			if result.err != nil {
+				r.skip(result.key, result.err)
+				continue
+			}
+			resourcesByKey[result.key] = result.resources
+		case <-runner.DoneChan():
+			break ReadLoop
+		}
+	}
+
+	if runner.Err() != nil {
+		// Routines still reading states block on sending their result, drain them so they exit
+		for range runner.Read() {
 		}
-		results = append(results, resources...)
+		return nil, runner.Err()
+	}
+
+	// Keep resources in enumeration order so results do not depend on reads scheduling
+	results := make([]resource.Resource, 0)
+	for _, key := range keys {
+		results = append(results, resourcesByKey[key]...)
 	}
 
 	return results, nil
 }
 
-func read(reader backend.Backend) (*states.State, error) {
-	state, err := readState(reader)
-	if err != nil {
-		return nil, err
+func (r *TerraformStateReader) readDataSources() bool {
+	return r.backendOptions != nil && r.backendOptions.DataSources
+}
+
+func (r *TerraformStateReader) continueOnError() bool {
+	return r.backendOptions != nil && r.backendOptions.ContinueOnError
+}
+
+// skip reports a state that cannot be read, the scan goes on without its resources
+func (r *TerraformStateReader) skip(path string, err error) {
+	config := r.config
+	config.Path = path
+	source := sourceName(config)
+	logrus.WithFields(logrus.Fields{
+		"source": source,
+		"err":    err.Error(),
+	}).Warn("Skipping state that cannot be read")
+	if r.alerter != nil {
+		r.alerter.SendAlert(source, NewSkippedStateAlert(source, err))
+	}
+}
+
+// sourceName returns the IaC source of a state as given to the from flag
+func sourceName(config config.SupplierConfig) string {
+	if config.Backend == backend.BackendKeyFile {
+		return fmt.Sprintf("%s://%s", TerraformStateReaderSupplier, config.Path)
 	}
-	return state, nil
+	return fmt.Sprintf("%s+%s://%s", TerraformStateReaderSupplier, config.Backend, config.Path)
+}
+
+func (r *TerraformStateReader) parallelism() int64 {
+	if r.backendOptions != nil && r.backendOptions.Parallelism > 0 {
+		return int64(r.backendOptions.Parallelism)
+	}
+	return backend.DefaultParallelism
+}
+
+func read(reader io.Reader) (*statefile.File, error) {
+	return statefile.Read(reader)
 }
 
-func readState(reader backend.Backend) (*states.State, error) {
-	state, err := statefile.Read(reader)
+func readState(reader io.Reader) (*states.State, error) {
+	file, err := read(reader)
 	if err != nil {
 		return nil, err
 	}
-	return state.State, nil
+	return file.State, nil
 }
@@ -4,21 +4,28 @@ import (
 	"encoding/json"
 	"os"
 	"path"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cloudskiff/driftctl/pkg/output"
 
+	"github.com/cloudskiff/driftctl/pkg/alerter"
 	"github.com/cloudskiff/driftctl/pkg/iac"
 	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
 	"github.com/cloudskiff/driftctl/pkg/remote/aws"
 	"github.com/cloudskiff/driftctl/pkg/remote/github"
 	"github.com/cloudskiff/driftctl/pkg/resource"
+	resourceaws "github.com/cloudskiff/driftctl/pkg/resource/aws"
 	"github.com/cloudskiff/driftctl/pkg/terraform"
 	"github.com/cloudskiff/driftctl/test/goldenfile"
 	"github.com/cloudskiff/driftctl/test/mocks"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/r3labs/diff/v2"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestReadStateValid(t *testing.T) {
@@ -38,6 +45,231 @@ func TestReadStateInvalid(t *testing.T) {
 	}
 }
 
+func TestTerraformStateReader_LegacyState(t *testing.T) {
+	library := terraform.NewProviderLibrary()
+	library.AddProvider(terraform.AWS, mocks.NewMockedGoldenTFProvider("ec2_ebs_volume", nil, false))
+
+	r := &TerraformStateReader{
+		config: config.SupplierConfig{
+			Path: "testdata/v3/ec2_ebs_volume.tfstate",
+		},
+		library:       library,
+		deserializers: iac.Deserializers(),
+	}
+
+	got, err := r.Resources()
+	assert.Nil(t, err)
+	assert.Len(t, got, 2)
+
+	want := []*resourceaws.AwsEbsVolume{
+		{
+			Id:               "vol-01ddc91d3d9d1318b",
+			Arn:              awssdk.String("arn:aws:ec2:eu-west-3:047081014315:volume/vol-01ddc91d3d9d1318b"),
+			AvailabilityZone: awssdk.String("eu-west-3a"),
+			Encrypted:        awssdk.Bool(false),
+			Iops:             awssdk.Int(100),
+			KmsKeyId:         awssdk.String(""),
+			Size:             awssdk.Int(8),
+			SnapshotId:       awssdk.String(""),
+			Tags:             map[string]string{"Name": "HelloWorld"},
+			Type:             awssdk.String("gp2"),
+		},
+		{
+			Id:               "vol-0123456789abcdef0",
+			AvailabilityZone: awssdk.String("eu-west-3b"),
+			Size:             awssdk.Int(100),
+			Tags:             map[string]string{},
+		},
+	}
+	// Modules are not read in a predictable order
+	volumes := make([]*resourceaws.AwsEbsVolume, 0, len(got))
+	for _, res := range got {
+		ebsVolume := res.(*resourceaws.AwsEbsVolume)
+		ebsVolume.CtyVal = nil
+		volumes = append(volumes, ebsVolume)
+	}
+	assert.ElementsMatch(t, want, volumes)
+}
+
+func TestTerraformStateReader_MultiplesStates(t *testing.T) {
+	tests := []struct {
+		name            string
+		path            string
+		continueOnError bool
+		wantIds         [][]string
+		wantSkipped     []string
+		wantErr         string
+	}{
+		{
+			name: "test states are read in parallel and returned in enumeration order",
+			path: "testdata/multiples/valid",
+			wantIds: [][]string{
+				{"vol-01ddc91d3d9d1318b", "vol-0123456789abcdef0"},
+				{"vol-0a1b2c3d4e5f60718", "vol-0fedcba9876543210"},
+			},
+		},
+		{
+			name:    "test reading stops at first failing state",
+			path:    "testdata/multiples",
+			wantErr: "Unable to read state testdata/multiples/invalid/broken.tfstate: ",
+		},
+		{
+			name:            "test failing states are skipped when continuing on errors",
+			path:            "testdata/multiples",
+			continueOnError: true,
+			wantIds: [][]string{
+				{"vol-01ddc91d3d9d1318b", "vol-0123456789abcdef0"},
+				{"vol-0a1b2c3d4e5f60718", "vol-0fedcba9876543210"},
+			},
+			wantSkipped: []string{
+				"tfstate://testdata/multiples/invalid/broken.tfstate",
+				"tfstate://testdata/multiples/invalid/corrupted.tfstate",
+			},
+		},
+		{
+			name:            "test missing state is skipped when continuing on errors",
+			path:            "testdata/multiples/missing.tfstate",
+			continueOnError: true,
+			wantSkipped:     []string{"tfstate://testdata/multiples/missing.tfstate"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			library := terraform.NewProviderLibrary()
+			library.AddProvider(terraform.AWS, mocks.NewMockedGoldenTFProvider("ec2_ebs_volume", nil, false))
+
+			alerter := alerter.NewAlerter()
+			r, err := NewReader(
+				config.SupplierConfig{Path: tt.path},
+				library,
+				&backend.Options{Parallelism: 2, ContinueOnError: tt.continueOnError},
+				nil,
+				alerter,
+				nil,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := r.Resources()
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.True(t, strings.HasPrefix(err.Error(), tt.wantErr), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			skipped := make([]string, 0)
+			for source, alerts := range alerter.Retrieve() {
+				for _, alert := range alerts {
+					assert.IsType(t, &SkippedStateAlert{}, alert)
+				}
+				skipped = append(skipped, source)
+			}
+			assert.ElementsMatch(t, tt.wantSkipped, skipped)
+			// Resources of a state are not returned in a predictable order, but states are
+			for _, wantIds := range tt.wantIds {
+				if !assert.GreaterOrEqual(t, len(got), len(wantIds)) {
+					return
+				}
+				ids := make([]string, 0, len(wantIds))
+				for _, res := range got[:len(wantIds)] {
+					ids = append(ids, res.TerraformId())
+				}
+				assert.ElementsMatch(t, wantIds, ids)
+				got = got[len(wantIds):]
+			}
+			assert.Empty(t, got)
+		})
+	}
+}
+
+func TestTerraformStateReader_FailingStateStopsReadingRoutines(t *testing.T) {
+	library := terraform.NewProviderLibrary()
+	library.AddProvider(terraform.AWS, mocks.NewMockedGoldenTFProvider("ec2_ebs_volume", nil, false))
+
+	r, err := NewReader(
+		config.SupplierConfig{Path: "testdata/multiples/invalid"},
+		library,
+		&backend.Options{Parallelism: 2},
+		nil,
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A first read starts routines living as long as the process, e.g. the opencensus worker
+	_, err = r.Resources()
+	assert.Error(t, err)
+
+	goroutines := runtime.NumGoroutine()
+	_, err = r.Resources()
+	assert.Error(t, err)
+	// Routines reading other states must exit once reading stopped at the first failing state
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > goroutines && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), goroutines)
+}
+
+func TestTerraformStateReader_DataSources(t *testing.T) {
+	tests := []struct {
+		name        string
+		dataSources bool
+		want        []resource.Resource
+	}{
+		{
+			name: "test data sources are ignored by default",
+			want: []resource.Resource{
+				resource.SerializedResource{Id: "vol-02862d9b39045a3a4", Type: "aws_ebs_volume"},
+			},
+		},
+		{
+			name:        "test resources referenced by data sources",
+			dataSources: true,
+			want: []resource.Resource{
+				resource.SerializedResource{Id: "vol-02862d9b39045a3a4", Type: "aws_ebs_volume"},
+				resource.ReferencedResource{
+					SerializedResource: resource.SerializedResource{Id: "Z08068311RGDXPHF8QE6M", Type: "aws_route53_zone"},
+				},
+				resource.ReferencedResource{
+					SerializedResource: resource.SerializedResource{Id: "vpc-0768e1fd0029e3fc3", Type: "aws_vpc"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			library := terraform.NewProviderLibrary()
+			library.AddProvider(terraform.AWS, mocks.NewMockedGoldenTFProvider("ec2_ebs_volume", nil, false))
+
+			r, err := NewReader(
+				config.SupplierConfig{Path: "testdata/data_sources/terraform.tfstate"},
+				library,
+				&backend.Options{DataSources: tt.dataSources},
+				nil,
+				nil,
+				nil,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := r.Resources()
+			assert.Nil(t, err)
+			if !assert.Len(t, got, len(tt.want)) {
+				return
+			}
+			// Managed resources are decoded, only their identifier is compared
+			assert.Equal(t, tt.want[0].TerraformId(), got[0].TerraformId())
+			assert.Equal(t, tt.want[0].TerraformType(), got[0].TerraformType())
+			assert.ElementsMatch(t, tt.want[1:], got[1:])
+		})
+	}
+}
+
 func TestTerraformStateReader_AWS_Resources(t *testing.T) {
 	tests := []struct {
 		name    string
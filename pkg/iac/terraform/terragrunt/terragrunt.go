@@ -0,0 +1,459 @@
+package terragrunt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform/lang"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/tfdir"
+)
+
+const TerragruntSupplier = "terragrunt"
+
+const configFile = "terragrunt.hcl"
+
+// cacheDir is where terragrunt downloads and initializes terraform modules of a unit
+const cacheDir = ".terragrunt-cache"
+
+// Unit is a terragrunt unit along with the state its remote_state configuration points to
+type Unit struct {
+	Dir   string
+	State config.SupplierConfig
+}
+
+// UnitError is returned for units whose state cannot be resolved
+type UnitError struct {
+	Dir string
+	err error
+}
+
+func (e *UnitError) Error() string {
+	return fmt.Sprintf("Unable to resolve state of terragrunt unit %s: %s", e.Dir, e.err)
+}
+
+// file is the content of a terragrunt configuration file needed to resolve its state
+type file struct {
+	path        string
+	include     hcl.Expression
+	locals      map[string]hcl.Expression
+	remoteState *hclsyntax.Body
+	isUnit      bool
+}
+
+// Discover walks a terragrunt repository and resolves the state of every unit found.
+// A unit is a directory whose terragrunt.hcl includes a parent configuration or has a terraform block,
+// other terragrunt.hcl files are only parent configurations. Units whose state cannot be resolved
+// and terragrunt.hcl files that cannot be parsed are returned as UnitError, along with units that could be resolved.
+func Discover(root string) ([]Unit, []*UnitError, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !info.IsDir() {
+		return nil, nil, errors.Errorf("%s is not a directory", root)
+	}
+
+	paths := make([]string, 0)
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			switch info.Name() {
+			case cacheDir, ".terraform", ".git":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Name() == configFile {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parser := hclparse.NewParser()
+	units := make([]Unit, 0, len(paths))
+	unitErrors := make([]*UnitError, 0)
+	for _, path := range paths {
+		dir := filepath.Dir(path)
+		f, err := parseFile(parser, path)
+		if err != nil {
+			// The configuration may only be a parent one, it is reported as a unit anyway
+			unitErrors = append(unitErrors, &UnitError{Dir: dir, err: err})
+			continue
+		}
+		if !f.isUnit {
+			logrus.WithFields(logrus.Fields{
+				"path": path,
+			}).Debug("Found terragrunt parent configuration")
+			continue
+		}
+
+		state, err := resolveUnit(parser, dir, f)
+		if err != nil {
+			unitErrors = append(unitErrors, &UnitError{Dir: dir, err: err})
+			continue
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"unit":    dir,
+			"backend": state.Backend,
+			"path":    state.Path,
+		}).Debug("Resolved state of terragrunt unit")
+
+		units = append(units, Unit{Dir: dir, State: *state})
+	}
+
+	return units, unitErrors, nil
+}
+
+// resolveUnit resolves the state from the remote_state block of the unit, or of the configuration it includes.
+// When remote_state cannot be evaluated statically, the backend initialized in the terragrunt cache is used.
+func resolveUnit(parser *hclparse.Parser, dir string, unit *file) (*config.SupplierConfig, error) {
+	state, err := resolveConfiguredState(parser, dir, unit)
+	if err == nil {
+		return state, nil
+	}
+
+	cached, cacheErr := resolveFromCache(dir)
+	if cacheErr != nil || cached == nil {
+		return nil, err
+	}
+	logrus.WithFields(logrus.Fields{
+		"unit": dir,
+		"err":  err.Error(),
+	}).Debug("Resolved state of terragrunt unit from its cache")
+	return cached, nil
+}
+
+func resolveConfiguredState(parser *hclparse.Parser, dir string, unit *file) (*config.SupplierConfig, error) {
+	if unit.remoteState != nil {
+		return resolveRemoteState(newEvalContext(dir, ""), unit)
+	}
+	if unit.include == nil {
+		return nil, errors.New("no remote_state configuration found")
+	}
+
+	parent, err := readInclude(parser, newEvalContext(dir, ""), unit)
+	if err != nil {
+		return nil, err
+	}
+	if parent.remoteState == nil {
+		return nil, errors.Errorf("no remote_state configuration found in %s", parent.path)
+	}
+	return resolveRemoteState(newEvalContext(dir, filepath.Dir(parent.path)), parent)
+}
+
+func readInclude(parser *hclparse.Parser, ctx *evalContext, unit *file) (*file, error) {
+	value, diags := unit.include.Value(ctx.hclContext(nil))
+	if diags.HasErrors() {
+		return nil, errors.Errorf("Unable to evaluate include path: %s", diags.Error())
+	}
+	value, err := convert.Convert(value, cty.String)
+	if err != nil || value.IsNull() || !value.IsKnown() {
+		return nil, errors.New("Unable to evaluate include path")
+	}
+	path := value.AsString()
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(unit.path), path)
+	}
+	return parseFile(parser, path)
+}
+
+func resolveRemoteState(ctx *evalContext, f *file) (*config.SupplierConfig, error) {
+	hclCtx := ctx.hclContext(ctx.resolveLocals(f.locals))
+
+	backendAttribute, exists := f.remoteState.Attributes["backend"]
+	if !exists {
+		return nil, errors.Errorf("remote_state of %s has no backend", f.path)
+	}
+	backendType, diags := backendAttribute.Expr.Value(hclCtx)
+	if diags.HasErrors() {
+		return nil, errors.Errorf("Unable to evaluate remote_state backend of %s: %s", f.path, diags.Error())
+	}
+	backendType, err := convert.Convert(backendType, cty.String)
+	if err != nil || backendType.IsNull() || !backendType.IsKnown() {
+		return nil, errors.Errorf("Unable to evaluate remote_state backend of %s", f.path)
+	}
+
+	backendConfig := make(map[string]interface{})
+	if attribute, exists := f.remoteState.Attributes["config"]; exists {
+		value, diags := attribute.Expr.Value(hclCtx)
+		if diags.HasErrors() {
+			return nil, errors.Errorf("Unable to evaluate remote_state config of %s: %s", f.path, diags.Error())
+		}
+		if !value.IsWhollyKnown() {
+			return nil, errors.Errorf("Unable to evaluate remote_state config of %s", f.path)
+		}
+		if block, ok := toInterface(value).(map[string]interface{}); ok {
+			backendConfig = block
+		}
+	}
+
+	return tfdir.ResolveBackend(ctx.unitDir, backendType.AsString(), backendConfig)
+}
+
+// resolveFromCache returns the state of the backend initialized by terragrunt, nil when the unit was not initialized
+func resolveFromCache(dir string) (*config.SupplierConfig, error) {
+	initialized := make([]string, 0)
+	err := filepath.Walk(filepath.Join(dir, cacheDir), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && info.Name() == ".terraform" {
+			if _, err := os.Stat(filepath.Join(path, "terraform.tfstate")); err == nil {
+				initialized = append(initialized, filepath.Dir(path))
+			}
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if os.IsNotExist(err) || len(initialized) == 0 {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Every initialized copy of the module uses the same backend, prefer the most recently initialized one
+	sort.Slice(initialized, func(i, j int) bool {
+		return modTime(initialized[i]) > modTime(initialized[j])
+	})
+	return tfdir.Resolve(initialized[0])
+}
+
+func modTime(dir string) int64 {
+	info, err := os.Stat(filepath.Join(dir, ".terraform", "terraform.tfstate"))
+	if err != nil {
+		return 0
+	}
+	return info.ModTime().UnixNano()
+}
+
+func parseFile(parser *hclparse.Parser, path string) (*file, error) {
+	hclFile, diags := parser.ParseHCLFile(path)
+	if diags.HasErrors() {
+		return nil, errors.Errorf("Unable to parse terragrunt configuration: %s", diags.Error())
+	}
+	body, ok := hclFile.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, errors.Errorf("Unable to parse terragrunt configuration %s", path)
+	}
+
+	f := &file{
+		path:   path,
+		locals: make(map[string]hcl.Expression),
+	}
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "include":
+			f.isUnit = true
+			// Only the first include is followed, it is the one holding remote_state in most repositories
+			if attribute, exists := block.Body.Attributes["path"]; exists && f.include == nil {
+				f.include = attribute.Expr
+			}
+		case "terraform":
+			f.isUnit = true
+		case "locals":
+			for name, attribute := range block.Body.Attributes {
+				f.locals[name] = attribute.Expr
+			}
+		case "remote_state":
+			f.remoteState = block.Body
+		}
+	}
+	return f, nil
+}
+
+// evalContext evaluates expressions the way terragrunt does for a unit, includeDir is the directory
+// of the included configuration when evaluating it
+type evalContext struct {
+	unitDir    string
+	includeDir string
+	functions  map[string]function.Function
+}
+
+func newEvalContext(unitDir, includeDir string) *evalContext {
+	ctx := &evalContext{
+		unitDir:    unitDir,
+		includeDir: includeDir,
+		functions:  make(map[string]function.Function),
+	}
+	for name, fn := range (&lang.Scope{BaseDir: unitDir, PureOnly: true}).Functions() {
+		ctx.functions[name] = fn
+	}
+	ctx.functions["find_in_parent_folders"] = ctx.findInParentFolders()
+	ctx.functions["path_relative_to_include"] = ctx.relativePath(func() (string, string) { return ctx.includeDir, ctx.unitDir })
+	ctx.functions["path_relative_from_include"] = ctx.relativePath(func() (string, string) { return ctx.unitDir, ctx.includeDir })
+	ctx.functions["get_terragrunt_dir"] = ctx.dir(func() string { return ctx.unitDir })
+	ctx.functions["get_parent_terragrunt_dir"] = ctx.dir(func() string {
+		if ctx.includeDir != "" {
+			return ctx.includeDir
+		}
+		return ctx.unitDir
+	})
+	ctx.functions["get_env"] = getEnv
+	return ctx
+}
+
+func (c *evalContext) hclContext(locals map[string]cty.Value) *hcl.EvalContext {
+	localsVal := cty.EmptyObjectVal
+	if len(locals) > 0 {
+		localsVal = cty.ObjectVal(locals)
+	}
+	return &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"local": localsVal,
+		},
+		Functions: c.functions,
+	}
+}
+
+// resolveLocals evaluates locals until none of them can be resolved anymore, as they may refer to each other.
+// Locals that cannot be evaluated statically are left out.
+func (c *evalContext) resolveLocals(exprs map[string]hcl.Expression) map[string]cty.Value {
+	locals := make(map[string]cty.Value)
+	for {
+		resolved := false
+		ctx := c.hclContext(locals)
+		for name, expr := range exprs {
+			if _, exists := locals[name]; exists {
+				continue
+			}
+			value, diags := expr.Value(ctx)
+			if diags.HasErrors() || !value.IsWhollyKnown() {
+				continue
+			}
+			locals[name] = value
+			resolved = true
+		}
+		if !resolved {
+			return locals
+		}
+	}
+}
+
+func (c *evalContext) findInParentFolders() function.Function {
+	return function.New(&function.Spec{
+		VarParam: &function.Parameter{Name: "args", Type: cty.String},
+		Type:     function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			name := configFile
+			if len(args) > 0 {
+				name = args[0].AsString()
+			}
+			dir, err := filepath.Abs(c.unitDir)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			for dir != filepath.Dir(dir) {
+				dir = filepath.Dir(dir)
+				candidate := filepath.Join(dir, name)
+				if _, err := os.Stat(candidate); err == nil {
+					return cty.StringVal(candidate), nil
+				}
+			}
+			if len(args) > 1 {
+				return args[1], nil
+			}
+			return cty.NilVal, errors.Errorf("Unable to find %s in parent folders of %s", name, c.unitDir)
+		},
+	})
+}
+
+func (c *evalContext) relativePath(dirs func() (string, string)) function.Function {
+	return function.New(&function.Spec{
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			from, to := dirs()
+			if from == "" || to == "" {
+				return cty.StringVal("."), nil
+			}
+			from, err := filepath.Abs(from)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			to, err = filepath.Abs(to)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			rel, err := filepath.Rel(from, to)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			return cty.StringVal(filepath.ToSlash(rel)), nil
+		},
+	})
+}
+
+func (c *evalContext) dir(dir func() string) function.Function {
+	return function.New(&function.Spec{
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			abs, err := filepath.Abs(dir())
+			if err != nil {
+				return cty.NilVal, err
+			}
+			return cty.StringVal(filepath.ToSlash(abs)), nil
+		},
+	})
+}
+
+var getEnv = function.New(&function.Spec{
+	Params:   []function.Parameter{{Name: "name", Type: cty.String}},
+	VarParam: &function.Parameter{Name: "default", Type: cty.String},
+	Type:     function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		if value, exists := os.LookupEnv(args[0].AsString()); exists {
+			return cty.StringVal(value), nil
+		}
+		if len(args) > 1 {
+			return args[1], nil
+		}
+		return cty.StringVal(""), nil
+	},
+})
+
+// toInterface converts a configuration value to the representation backend configurations are read with
+func toInterface(value cty.Value) interface{} {
+	if value.IsNull() {
+		return nil
+	}
+	ty := value.Type()
+	switch {
+	case ty.IsObjectType() || ty.IsMapType():
+		result := make(map[string]interface{})
+		for it := value.ElementIterator(); it.Next(); {
+			key, element := it.Element()
+			result[key.AsString()] = toInterface(element)
+		}
+		return result
+	case ty.IsTupleType() || ty.IsListType() || ty.IsSetType():
+		result := make([]interface{}, 0)
+		for it := value.ElementIterator(); it.Next(); {
+			_, element := it.Element()
+			result = append(result, toInterface(element))
+		}
+		return result
+	}
+	str, err := convert.Convert(value, cty.String)
+	if err != nil {
+		return nil
+	}
+	return str.AsString()
+}
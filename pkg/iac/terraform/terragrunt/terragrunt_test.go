@@ -0,0 +1,93 @@
+package terragrunt
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+)
+
+func TestDiscover(t *testing.T) {
+	tests := []struct {
+		name       string
+		root       string
+		env        map[string]string
+		want       []Unit
+		wantErrors []string
+		wantErr    string
+	}{
+		{
+			name: "test units of repository",
+			root: "testdata/repo",
+			want: []Unit{
+				{
+					Dir:   "testdata/repo/dev/account",
+					State: config.SupplierConfig{Key: "tfstate", Backend: "s3", Path: "tfstates-123456789012/dev/account/terraform.tfstate"},
+				},
+				{
+					Dir:   "testdata/repo/dev/db",
+					State: config.SupplierConfig{Key: "tfstate", Backend: "", Path: "testdata/repo/dev/db/db.tfstate"},
+				},
+				{
+					Dir:   "testdata/repo/prod/app",
+					State: config.SupplierConfig{Key: "tfstate", Backend: "s3", Path: "acme-eu-west-3-tfstates/prod/app/terraform.tfstate"},
+				},
+				{
+					Dir:   "testdata/repo/prod/vpc",
+					State: config.SupplierConfig{Key: "tfstate", Backend: "s3", Path: "acme-eu-west-3-tfstates/prod/vpc/terraform.tfstate"},
+				},
+			},
+			wantErrors: []string{
+				`Unable to resolve state of terragrunt unit testdata/repo/dev/invalid: Unable to parse terragrunt configuration: testdata/repo/dev/invalid/terragrunt.hcl:9,1-1: Invalid expression; Expected the start of an expression, but found an invalid expression token.`,
+				`Unable to resolve state of terragrunt unit testdata/repo/dev/unresolvable: Unable to evaluate remote_state config of testdata/repo/dev/unresolvable/terragrunt.hcl: testdata/repo/dev/unresolvable/terragrunt.hcl:8,26-44: Call to unknown function; There is no function named "get_aws_account_id".`,
+			},
+		},
+		{
+			name: "test units of sub directory with environment",
+			root: "testdata/repo/prod",
+			env: map[string]string{
+				"TG_STATE_REGION": "us-east-1",
+			},
+			want: []Unit{
+				{
+					Dir:   "testdata/repo/prod/app",
+					State: config.SupplierConfig{Key: "tfstate", Backend: "s3", Path: "acme-us-east-1-tfstates/prod/app/terraform.tfstate"},
+				},
+				{
+					Dir:   "testdata/repo/prod/vpc",
+					State: config.SupplierConfig{Key: "tfstate", Backend: "s3", Path: "acme-us-east-1-tfstates/prod/vpc/terraform.tfstate"},
+				},
+			},
+			wantErrors: []string{},
+		},
+		{
+			name:    "test missing directory",
+			root:    "testdata/missing",
+			wantErr: "stat testdata/missing: no such file or directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				os.Setenv(key, value)
+				defer os.Unsetenv(key)
+			}
+
+			got, unitErrors, err := Discover(tt.root)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+
+			messages := make([]string, 0, len(unitErrors))
+			for _, unitErr := range unitErrors {
+				messages = append(messages, unitErr.Error())
+			}
+			assert.Equal(t, tt.wantErrors, messages)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+terraform {
+  source = "../../modules/db"
+}
+
+remote_state {
+  backend = "local"
+  config = {
+    path = "db.tfstate"
+  }
+}
@@ -0,0 +1,8 @@
+terraform {
+  source = "../../modules/invalid"
+}
+
+remote_state {
+  backend = "local"
+  config = {
+    path = "invalid.tfstate"
@@ -0,0 +1,11 @@
+terraform {
+  source = "../../modules/vpc"
+}
+
+remote_state {
+  backend = "s3"
+  config = {
+    bucket = "tfstates-${get_aws_account_id()}"
+    key    = "dev/unresolvable/terraform.tfstate"
+  }
+}
@@ -0,0 +1,7 @@
+include {
+  path = find_in_parent_folders()
+}
+
+terraform {
+  source = "git::https://example.com/modules.git//vpc?ref=v1.2.0"
+}
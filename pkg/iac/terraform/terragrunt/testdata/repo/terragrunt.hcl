@@ -0,0 +1,15 @@
+locals {
+  region = get_env("TG_STATE_REGION", "eu-west-3")
+  bucket = "acme-${local.region}-tfstates"
+}
+
+remote_state {
+  backend = "s3"
+  config = {
+    bucket         = local.bucket
+    key            = "${path_relative_to_include()}/terraform.tfstate"
+    region         = local.region
+    encrypt        = true
+    dynamodb_table = "terraform-locks"
+  }
+}
@@ -0,0 +1,8 @@
+terraform {
+  backend "azurerm" {
+    resource_group_name  = "states"
+    storage_account_name = "myaccount"
+    container_name       = "tfstate"
+    key                  = "network.tfstate"
+  }
+}
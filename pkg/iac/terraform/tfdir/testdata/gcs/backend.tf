@@ -0,0 +1,6 @@
+terraform {
+  backend "gcs" {
+    bucket = "my-bucket"
+    prefix = "network"
+  }
+}
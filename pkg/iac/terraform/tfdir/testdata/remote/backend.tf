@@ -0,0 +1,9 @@
+terraform {
+  backend "remote" {
+    organization = "my-org"
+
+    workspaces {
+      prefix = "network-"
+    }
+  }
+}
@@ -0,0 +1,10 @@
+terraform {
+  backend "remote" {
+    hostname     = "tfe.example.com"
+    organization = "my-org"
+
+    workspaces {
+      name = "network"
+    }
+  }
+}
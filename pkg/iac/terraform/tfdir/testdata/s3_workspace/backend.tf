@@ -0,0 +1,7 @@
+terraform {
+  backend "s3" {
+    bucket               = "my-bucket"
+    key                  = "network/terraform.tfstate"
+    workspace_key_prefix = "workspaces"
+  }
+}
@@ -0,0 +1,355 @@
+package tfdir
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pkg/errors"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+)
+
+const TerraformDirSupplier = "tfdir"
+
+const defaultWorkspace = "default"
+
+const defaultStateFilename = "terraform.tfstate"
+
+// terraformBackend is a backend type along with its configuration, as found in a backend block
+// or in .terraform/terraform.tfstate once terraform init has been run
+type terraformBackend struct {
+	Type   string                 `json:"type"`
+	Config map[string]interface{} `json:"config"`
+}
+
+func (b *terraformBackend) getString(key string) string {
+	value, _ := b.Config[key].(string)
+	return value
+}
+
+func (b *terraformBackend) getBlock(key string) map[string]interface{} {
+	switch block := b.Config[key].(type) {
+	case map[string]interface{}:
+		return block
+	case []interface{}:
+		if len(block) > 0 {
+			value, _ := block[0].(map[string]interface{})
+			return value
+		}
+	}
+	return nil
+}
+
+// Resolve returns the configuration of the state of the terraform root module in dir,
+// for the currently selected workspace
+func Resolve(dir string) (*config.SupplierConfig, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, errors.Errorf("%s is not a directory", dir)
+	}
+
+	tfBackend, err := readBackend(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace, err := currentWorkspace(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	stateConfig, err := resolveStateConfig(dir, tfBackend, workspace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to resolve state of %s", dir)
+	}
+	return stateConfig, nil
+}
+
+// ResolveBackend returns the configuration of the default workspace state stored by a backend,
+// relative paths of a local backend are relative to dir
+func ResolveBackend(dir, backendType string, backendConfig map[string]interface{}) (*config.SupplierConfig, error) {
+	return resolveStateConfig(dir, &terraformBackend{Type: backendType, Config: backendConfig}, defaultWorkspace)
+}
+
+func resolveStateConfig(dir string, tfBackend *terraformBackend, workspace string) (*config.SupplierConfig, error) {
+	stateConfig := config.SupplierConfig{
+		Key: state.TerraformStateReaderSupplier,
+	}
+
+	switch tfBackend.Type {
+	case "local":
+		statePath := tfBackend.getString("path")
+		if statePath == "" {
+			statePath = defaultStateFilename
+		}
+		if workspace != defaultWorkspace {
+			workspaceDir := tfBackend.getString("workspace_dir")
+			if workspaceDir == "" {
+				workspaceDir = "terraform.tfstate.d"
+			}
+			// path only applies to the default workspace, other workspaces always use the default file name
+			statePath = filepath.Join(workspaceDir, workspace, defaultStateFilename)
+		}
+		if !filepath.IsAbs(statePath) {
+			statePath = filepath.Join(dir, statePath)
+		}
+		stateConfig.Backend = backend.BackendKeyFile
+		stateConfig.Path = statePath
+	case "s3":
+		bucket, key := tfBackend.getString("bucket"), tfBackend.getString("key")
+		if bucket == "" || key == "" {
+			return nil, errors.New("s3 backend requires bucket and key")
+		}
+		stateConfig.Backend = backend.BackendKeyS3
+		stateConfig.Path = path.Join(bucket, key)
+		if workspace != defaultWorkspace {
+			stateConfig.Workspaces = []string{workspace}
+			stateConfig.WorkspaceKeyPrefix = tfBackend.getString("workspace_key_prefix")
+		}
+	case "http":
+		address := tfBackend.getString("address")
+		u, err := url.Parse(address)
+		if err != nil {
+			return nil, errors.Wrap(err, "Unable to parse http backend address")
+		}
+		if u.Scheme != backend.BackendKeyHTTP && u.Scheme != backend.BackendKeyHTTPS {
+			return nil, errors.Errorf("http backend address must be an http or https URL, got '%s'", address)
+		}
+		stateConfig.Backend = u.Scheme
+		stateConfig.Path = strings.TrimPrefix(address, fmt.Sprintf("%s://", u.Scheme))
+	case "gcs":
+		bucket := tfBackend.getString("bucket")
+		if bucket == "" {
+			return nil, errors.New("gcs backend requires bucket")
+		}
+		stateConfig.Backend = backend.BackendKeyGS
+		stateConfig.Path = path.Join(bucket, tfBackend.getString("prefix"), fmt.Sprintf("%s.tfstate", workspace))
+	case "azurerm":
+		account, container, key := tfBackend.getString("storage_account_name"), tfBackend.getString("container_name"), tfBackend.getString("key")
+		if account == "" || container == "" || key == "" {
+			return nil, errors.New("azurerm backend requires storage_account_name, container_name and key")
+		}
+		if workspace != defaultWorkspace {
+			key = fmt.Sprintf("%senv:%s", key, workspace)
+		}
+		stateConfig.Backend = backend.BackendKeyAzureRM
+		stateConfig.Path = path.Join(account, container, key)
+	case "remote":
+		organization := tfBackend.getString("organization")
+		workspaces := tfBackend.getBlock("workspaces")
+		name, _ := workspaces["name"].(string)
+		prefix, _ := workspaces["prefix"].(string)
+		if organization == "" || (name == "" && prefix == "") {
+			return nil, errors.New("remote backend requires organization and a workspace name or prefix")
+		}
+		stateConfig.Backend = backend.BackendKeyTFCloud
+		if hostname := tfBackend.getString("hostname"); hostname != "" {
+			stateConfig.TFCloudEndpoint = fmt.Sprintf("https://%s/api/v2", hostname)
+		}
+		switch {
+		case name != "":
+			stateConfig.Path = path.Join(organization, name)
+		case workspace != defaultWorkspace:
+			stateConfig.Path = path.Join(organization, prefix+workspace)
+		default:
+			stateConfig.Path = path.Join(organization, prefix+"*")
+		}
+	case "consul":
+		statePath := tfBackend.getString("path")
+		if statePath == "" {
+			return nil, errors.New("consul backend requires path")
+		}
+		if workspace != defaultWorkspace {
+			statePath = fmt.Sprintf("%s-env:%s", statePath, workspace)
+		}
+		stateConfig.Backend = backend.BackendKeyConsul
+		stateConfig.Path = statePath
+	default:
+		return nil, errors.Errorf("Unsupported terraform backend '%s'", tfBackend.Type)
+	}
+
+	return &stateConfig, nil
+}
+
+// readBackend prefers the backend saved by terraform init, as it contains partial configuration
+// given on the command line, then falls back to the backend block of the module
+func readBackend(dir string) (*terraformBackend, error) {
+	initializedBackend, err := readInitializedBackend(dir)
+	if err != nil || initializedBackend != nil {
+		return initializedBackend, err
+	}
+
+	configuredBackend, err := readConfiguredBackend(dir)
+	if err != nil || configuredBackend != nil {
+		return configuredBackend, err
+	}
+
+	return &terraformBackend{Type: "local"}, nil
+}
+
+func readInitializedBackend(dir string) (*terraformBackend, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dir, ".terraform", "terraform.tfstate"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	backendState := struct {
+		Backend *terraformBackend `json:"backend"`
+	}{}
+	if err := json.Unmarshal(content, &backendState); err != nil {
+		return nil, errors.Wrap(err, "Unable to read .terraform/terraform.tfstate")
+	}
+	if backendState.Backend == nil || backendState.Backend.Type == "" {
+		return nil, nil
+	}
+
+	return backendState.Backend, nil
+}
+
+func readConfiguredBackend(dir string) (*terraformBackend, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+	jsonFiles, err := filepath.Glob(filepath.Join(dir, "*.tf.json"))
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, jsonFiles...)
+
+	parser := hclparse.NewParser()
+	for _, file := range files {
+		var hclFile *hcl.File
+		var diags hcl.Diagnostics
+		if strings.HasSuffix(file, ".json") {
+			hclFile, diags = parser.ParseJSONFile(file)
+		} else {
+			hclFile, diags = parser.ParseHCLFile(file)
+		}
+		if diags.HasErrors() {
+			return nil, errors.Errorf("Unable to parse terraform configuration: %s", diags.Error())
+		}
+
+		content, _, diags := hclFile.Body.PartialContent(terraformSchema)
+		if diags.HasErrors() {
+			return nil, errors.Errorf("Unable to parse terraform configuration: %s", diags.Error())
+		}
+		for _, terraformBlock := range content.Blocks {
+			terraformContent, _, diags := terraformBlock.Body.PartialContent(backendSchema)
+			if diags.HasErrors() {
+				return nil, errors.Errorf("Unable to parse terraform configuration: %s", diags.Error())
+			}
+			for _, backendBlock := range terraformContent.Blocks {
+				return &terraformBackend{
+					Type:   backendBlock.Labels[0],
+					Config: readBlock(backendBlock.Body),
+				}, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+var terraformSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{{Type: "terraform"}},
+}
+
+var backendSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{{Type: "backend", LabelNames: []string{"type"}}},
+}
+
+// readBlock reads static string attributes and nested blocks of a block body.
+// Attributes that cannot be evaluated without context (variables are not allowed in backend blocks anyway) are ignored
+func readBlock(body hcl.Body) map[string]interface{} {
+	// Nested blocks of JSON bodies cannot be told apart from attributes without a schema, they are read as objects
+	expressions := make(map[string]hcl.Expression)
+	syntaxBody, isSyntaxBody := body.(*hclsyntax.Body)
+	if isSyntaxBody {
+		for name, attribute := range syntaxBody.Attributes {
+			expressions[name] = attribute.Expr
+		}
+	} else {
+		attributes, _ := body.JustAttributes()
+		for name, attribute := range attributes {
+			expressions[name] = attribute.Expr
+		}
+	}
+
+	values := make(map[string]interface{})
+	for name, expr := range expressions {
+		value, diags := expr.Value(&hcl.EvalContext{})
+		if diags.HasErrors() {
+			continue
+		}
+		if value, ok := readValue(value); ok {
+			values[name] = value
+		}
+	}
+	if isSyntaxBody {
+		for _, block := range syntaxBody.Blocks {
+			values[block.Type] = readBlock(block.Body)
+		}
+	}
+	return values
+}
+
+func readValue(value cty.Value) (interface{}, bool) {
+	if !value.IsWhollyKnown() || value.IsNull() {
+		return nil, false
+	}
+	if value.Type().IsObjectType() || value.Type().IsMapType() {
+		values := make(map[string]interface{})
+		for it := value.ElementIterator(); it.Next(); {
+			key, element := it.Element()
+			if element, ok := readValue(element); ok {
+				values[key.AsString()] = element
+			}
+		}
+		return values, true
+	}
+	value, err := convert.Convert(value, cty.String)
+	if err != nil {
+		return nil, false
+	}
+	return value.AsString(), true
+}
+
+// currentWorkspace returns the workspace selected with TF_WORKSPACE or terraform workspace select
+func currentWorkspace(dir string) (string, error) {
+	if workspace := os.Getenv("TF_WORKSPACE"); workspace != "" {
+		return workspace, nil
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, ".terraform", "environment"))
+	if os.IsNotExist(err) {
+		return defaultWorkspace, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if workspace := strings.TrimSpace(string(content)); workspace != "" {
+		return workspace, nil
+	}
+	return defaultWorkspace, nil
+}
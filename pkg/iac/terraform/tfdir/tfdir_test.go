@@ -0,0 +1,187 @@
+package tfdir
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		dir       string
+		workspace string
+		want      *config.SupplierConfig
+		err       string
+	}{
+		{
+			name: "no backend defaults to local state",
+			dir:  "testdata/local",
+			want: &config.SupplierConfig{
+				Key:     "tfstate",
+				Backend: "",
+				Path:    "testdata/local/terraform.tfstate",
+			},
+		},
+		{
+			name:      "local backend with workspace",
+			dir:       "testdata/local_custom_path",
+			workspace: "prod",
+			want: &config.SupplierConfig{
+				Key:     "tfstate",
+				Backend: "",
+				Path:    "testdata/local_custom_path/terraform.tfstate.d/prod/terraform.tfstate",
+			},
+		},
+		{
+			name: "local backend with custom path",
+			dir:  "testdata/local_custom_path",
+			want: &config.SupplierConfig{
+				Key:     "tfstate",
+				Backend: "",
+				Path:    "testdata/local_custom_path/states/prod.tfstate",
+			},
+		},
+		{
+			name: "s3 backend",
+			dir:  "testdata/s3",
+			want: &config.SupplierConfig{
+				Key:     "tfstate",
+				Backend: "s3",
+				Path:    "my-bucket/network/terraform.tfstate",
+			},
+		},
+		{
+			name: "s3 backend with selected workspace",
+			dir:  "testdata/s3_workspace",
+			want: &config.SupplierConfig{
+				Key:                "tfstate",
+				Backend:            "s3",
+				Path:               "my-bucket/network/terraform.tfstate",
+				Workspaces:         []string{"prod"},
+				WorkspaceKeyPrefix: "workspaces",
+			},
+		},
+		{
+			name: "initialized backend",
+			dir:  "testdata/initialized",
+			want: &config.SupplierConfig{
+				Key:     "tfstate",
+				Backend: "s3",
+				Path:    "my-initialized-bucket/compute/terraform.tfstate",
+			},
+		},
+		{
+			name: "http backend",
+			dir:  "testdata/http",
+			want: &config.SupplierConfig{
+				Key:     "tfstate",
+				Backend: "https",
+				Path:    "example.com/states/network",
+			},
+		},
+		{
+			name:      "gcs backend",
+			dir:       "testdata/gcs",
+			workspace: "staging",
+			want: &config.SupplierConfig{
+				Key:     "tfstate",
+				Backend: "gs",
+				Path:    "my-bucket/network/staging.tfstate",
+			},
+		},
+		{
+			name:      "azurerm backend",
+			dir:       "testdata/azurerm",
+			workspace: "staging",
+			want: &config.SupplierConfig{
+				Key:     "tfstate",
+				Backend: "azurerm",
+				Path:    "myaccount/tfstate/network.tfstateenv:staging",
+			},
+		},
+		{
+			name: "remote backend with workspace prefix",
+			dir:  "testdata/remote",
+			want: &config.SupplierConfig{
+				Key:     "tfstate",
+				Backend: "tfcloud",
+				Path:    "my-org/network-*",
+			},
+		},
+		{
+			name:      "remote backend with selected workspace",
+			dir:       "testdata/remote",
+			workspace: "prod",
+			want: &config.SupplierConfig{
+				Key:     "tfstate",
+				Backend: "tfcloud",
+				Path:    "my-org/network-prod",
+			},
+		},
+		{
+			name: "remote backend of terraform enterprise",
+			dir:  "testdata/remote_enterprise",
+			want: &config.SupplierConfig{
+				Key:             "tfstate",
+				Backend:         "tfcloud",
+				Path:            "my-org/network",
+				TFCloudEndpoint: "https://tfe.example.com/api/v2",
+			},
+		},
+		{
+			name: "backend in json configuration",
+			dir:  "testdata/json",
+			want: &config.SupplierConfig{
+				Key:     "tfstate",
+				Backend: "tfcloud",
+				Path:    "my-org/network",
+			},
+		},
+		{
+			name: "consul backend",
+			dir:  "testdata/consul",
+			want: &config.SupplierConfig{
+				Key:     "tfstate",
+				Backend: "consul",
+				Path:    "states/network",
+			},
+		},
+		{
+			name: "unsupported backend",
+			dir:  "testdata/unsupported",
+			err:  "Unable to resolve state of testdata/unsupported: Unsupported terraform backend 'pg'",
+		},
+		{
+			name: "invalid configuration",
+			dir:  "testdata/invalid",
+			err:  "Unable to parse terraform configuration: testdata/invalid/backend.tf:3,1-1: Argument or block definition required; An argument or block definition is required here.",
+		},
+		{
+			name: "missing directory",
+			dir:  "testdata/missing",
+			err:  "stat testdata/missing: no such file or directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.workspace != "" {
+				os.Setenv("TF_WORKSPACE", tt.workspace)
+				defer os.Unsetenv("TF_WORKSPACE")
+			}
+
+			got, err := Resolve(tt.dir)
+			if err != nil && err.Error() != tt.err {
+				t.Fatalf("Expected error '%s', got '%s'", tt.err, err.Error())
+			}
+			if err == nil && tt.err != "" {
+				t.Fatalf("Expected error '%s' but got nil", tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
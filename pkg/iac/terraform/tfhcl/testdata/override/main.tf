@@ -0,0 +1,23 @@
+variable "environment" {
+  default = "dev"
+}
+
+locals {
+  prefix = "billing-${var.environment}"
+}
+
+resource "aws_s3_bucket" "assets" {
+  bucket = "${local.prefix}-assets"
+}
+
+resource "aws_iam_role" "worker" {
+  name = "${local.prefix}-worker"
+}
+
+resource "aws_iam_user" "owner" {
+  force_destroy = true
+}
+
+resource "aws_sqs_queue" "jobs" {
+  name = "${local.prefix}-jobs"
+}
@@ -0,0 +1,11 @@
+variable "environment" {
+  default = "prod"
+}
+
+resource "aws_s3_bucket" "assets" {
+  count = 2
+}
+
+resource "aws_iam_user" "owner" {
+  name = "${local.prefix}-owner"
+}
@@ -0,0 +1,62 @@
+variable "environment" {
+  type    = string
+  default = "dev"
+}
+
+variable "project" {
+  type = string
+}
+
+variable "team" {
+  type = string
+}
+
+locals {
+  prefix = "${var.project}-${var.environment}"
+}
+
+resource "aws_s3_bucket" "assets" {
+  bucket = "${local.prefix}-assets"
+  acl    = "private"
+
+  versioning {
+    enabled = true
+  }
+}
+
+resource "aws_s3_bucket_policy" "assets" {
+  bucket = aws_s3_bucket.assets.id
+  policy = data.aws_iam_policy_document.assets.json
+}
+
+resource "aws_s3_bucket" "logs" {
+  bucket_prefix = "logs-"
+}
+
+resource "aws_s3_bucket" "replicas" {
+  count  = 2
+  bucket = "${local.prefix}-replica-${count.index}"
+}
+
+resource "aws_iam_role" "worker" {
+  name               = format("%s-worker", local.prefix)
+  assume_role_policy = file("assume-role-policy.json")
+}
+
+resource "aws_iam_role_policy" "worker" {
+  name   = "worker"
+  role   = aws_iam_role.worker.id
+  policy = "{}"
+}
+
+resource "aws_iam_user" "owner" {
+  name = "${var.team}-owner"
+}
+
+resource "aws_sqs_queue" "jobs" {
+  name = "${local.prefix}-jobs"
+}
+
+module "network" {
+  source = "./modules/network"
+}
@@ -0,0 +1,29 @@
+variable "buckets" {
+  type = list(string)
+}
+
+variable "tags" {
+  type = map(string)
+}
+
+variable "index" {
+  type = number
+}
+
+variable "suffix" {}
+
+resource "aws_s3_bucket" "first" {
+  bucket = var.buckets[0]
+}
+
+resource "aws_s3_bucket" "tagged" {
+  bucket = "${var.tags["team"]}-assets"
+}
+
+resource "aws_iam_role" "indexed" {
+  name = "role-${var.index}"
+}
+
+resource "aws_iam_user" "suffixed" {
+  name = "user-${var.suffix}"
+}
@@ -0,0 +1,548 @@
+package tfhcl
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform/lang"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+const TerraformHCLSupplier = "tfhcl"
+
+// identifierAttributes lists, for resource types whose terraform id can be known before apply,
+// the attributes that compose the id. Composite ids are joined with a colon.
+var identifierAttributes = map[string][]string{
+	"aws_db_instance":                       {"identifier"},
+	"aws_db_subnet_group":                   {"name"},
+	"aws_dynamodb_table":                    {"name"},
+	"aws_ecr_repository":                    {"name"},
+	"aws_iam_role":                          {"name"},
+	"aws_iam_role_policy":                   {"role", "name"},
+	"aws_iam_user":                          {"name"},
+	"aws_iam_user_policy":                   {"user", "name"},
+	"aws_key_pair":                          {"key_name"},
+	"aws_kms_alias":                         {"name"},
+	"aws_lambda_function":                   {"function_name"},
+	"aws_s3_bucket":                         {"bucket"},
+	"aws_s3_bucket_analytics_configuration": {"bucket", "name"},
+	"aws_s3_bucket_inventory":               {"bucket", "name"},
+	"aws_s3_bucket_metric":                  {"bucket", "name"},
+	"aws_s3_bucket_notification":            {"bucket"},
+	"aws_s3_bucket_policy":                  {"bucket"},
+	"github_repository":                     {"name"},
+}
+
+var moduleSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "variable", LabelNames: []string{"name"}},
+		{Type: "locals"},
+		{Type: "resource", LabelNames: []string{"type", "name"}},
+		{Type: "module", LabelNames: []string{"name"}},
+	},
+}
+
+var variableSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "type"},
+		{Name: "default"},
+	},
+}
+
+// hclResource is a resource block along with expressions of its identifier attributes
+type hclResource struct {
+	Type string
+	Name string
+	// attributes are expressions of identifier attributes, count and for_each set in the block or its overrides
+	attributes  map[string]hcl.Expression
+	identifiers []hcl.Expression
+	id          string
+}
+
+// module is the content of the terraform configuration files of a directory
+type module struct {
+	variables map[string]cty.Value
+	// variableTypes are type constraints of variables declaring one
+	variableTypes map[string]cty.Type
+	locals        map[string]hcl.Expression
+	resources     []*hclResource
+}
+
+// TerraformHCLReader reads resources from terraform configuration files, without any state.
+// Only resources whose identifier is a literal, or can be resolved from variables and locals, are returned.
+// They only carry their identifier, they are considered managed without comparing attributes.
+type TerraformHCLReader struct {
+	config     config.SupplierConfig
+	typeFilter filter.TypeFilter
+}
+
+func NewReader(config config.SupplierConfig, typeFilter filter.TypeFilter) (*TerraformHCLReader, error) {
+	if config.Path == "" {
+		return nil, errors.New("Unable to read terraform configuration, a directory must be provided")
+	}
+	return &TerraformHCLReader{config: config, typeFilter: typeFilter}, nil
+}
+
+func (r *TerraformHCLReader) Resources() ([]resource.Resource, error) {
+	info, err := os.Stat(r.config.Path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, errors.Errorf("%s is not a directory", r.config.Path)
+	}
+
+	mod, err := r.parseModule()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.readVariableValues(mod); err != nil {
+		return nil, err
+	}
+
+	r.resolve(mod)
+
+	results := make([]resource.Resource, 0, len(mod.resources))
+	for _, res := range mod.resources {
+		if res.id == "" {
+			logrus.WithFields(logrus.Fields{
+				"type": res.Type,
+				"name": res.Name,
+			}).Debug("Ignored resource whose identifier cannot be resolved from configuration")
+			continue
+		}
+		// Ignored types are still resolved, other resources may refer to them
+		if r.typeFilter != nil && r.typeFilter.IsTypeIgnored(res.Type) {
+			continue
+		}
+		logrus.WithFields(logrus.Fields{
+			"id":   res.id,
+			"type": res.Type,
+		}).Debug("Found resource in terraform configuration")
+		results = append(results, resource.SerializedResource{
+			Id:   res.id,
+			Type: res.Type,
+		})
+	}
+	return results, nil
+}
+
+// parseModule reads blocks of the root module, child modules are not read.
+// Override files are merged once every other file is read, in lexical order as terraform does.
+func (r *TerraformHCLReader) parseModule() (*module, error) {
+	files, overrideFiles, err := configurationFiles(r.config.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	mod := &module{
+		variables:     make(map[string]cty.Value),
+		variableTypes: make(map[string]cty.Type),
+		locals:        make(map[string]hcl.Expression),
+		resources:     make([]*hclResource, 0),
+	}
+
+	parser := hclparse.NewParser()
+	for _, file := range files {
+		content, err := parseModuleFile(parser, file)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, block := range content.Blocks {
+			switch block.Type {
+			case "variable":
+				name := block.Labels[0]
+				mod.variables[name] = cty.DynamicVal
+				variable := readVariable(block)
+				if variable.hasDefault {
+					mod.variables[name] = variable.defaultValue
+				}
+				if variable.hasType {
+					mod.variableTypes[name] = variable.ty
+				}
+			case "locals":
+				if err := readLocals(mod, block); err != nil {
+					return nil, err
+				}
+			case "resource":
+				if res := r.readResource(block); res != nil {
+					mod.resources = append(mod.resources, res)
+				}
+			case "module":
+				logrus.WithFields(logrus.Fields{
+					"module": block.Labels[0],
+				}).Debug("Ignored module call, resources of child modules are not read from configuration")
+			}
+		}
+	}
+
+	for _, file := range overrideFiles {
+		content, err := parseModuleFile(parser, file)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.mergeOverride(mod, content); err != nil {
+			return nil, err
+		}
+	}
+
+	mod.resources = identifiableResources(mod.resources)
+	return mod, nil
+}
+
+func parseModuleFile(parser *hclparse.Parser, file string) (*hcl.BodyContent, error) {
+	hclFile, diags := parseFile(parser, file)
+	if diags.HasErrors() {
+		return nil, errors.Errorf("Unable to parse terraform configuration: %s", diags.Error())
+	}
+	content, _, diags := hclFile.Body.PartialContent(moduleSchema)
+	if diags.HasErrors() {
+		return nil, errors.Errorf("Unable to parse terraform configuration: %s", diags.Error())
+	}
+	return content, nil
+}
+
+// mergeOverride merges blocks of an override file into the module.
+// Attributes set in an override block replace the ones of the block with the same labels, other ones are kept.
+func (r *TerraformHCLReader) mergeOverride(mod *module, content *hcl.BodyContent) error {
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "variable":
+			name := block.Labels[0]
+			if _, declared := mod.variables[name]; !declared {
+				continue
+			}
+			variable := readVariable(block)
+			if variable.hasDefault {
+				mod.variables[name] = variable.defaultValue
+			}
+			if variable.hasType {
+				mod.variableTypes[name] = variable.ty
+			}
+		case "locals":
+			if err := readLocals(mod, block); err != nil {
+				return err
+			}
+		case "resource":
+			override := r.readResource(block)
+			if override == nil {
+				continue
+			}
+			for _, res := range mod.resources {
+				if res.Type != override.Type || res.Name != override.Name {
+					continue
+				}
+				for name, expr := range override.attributes {
+					res.attributes[name] = expr
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func readLocals(mod *module, block *hcl.Block) error {
+	attributes, diags := block.Body.JustAttributes()
+	if diags.HasErrors() {
+		return errors.Errorf("Unable to parse terraform configuration: %s", diags.Error())
+	}
+	for name, attribute := range attributes {
+		mod.locals[name] = attribute.Expr
+	}
+	return nil
+}
+
+// variable is the type constraint and default value of a variable block, when they are set and valid
+type variable struct {
+	ty           cty.Type
+	hasType      bool
+	defaultValue cty.Value
+	hasDefault   bool
+}
+
+func readVariable(block *hcl.Block) variable {
+	result := variable{}
+	content, _, diags := block.Body.PartialContent(variableSchema)
+	if diags.HasErrors() {
+		return result
+	}
+	if attribute, exists := content.Attributes["type"]; exists {
+		ty, diags := typeexpr.TypeConstraint(attribute.Expr)
+		if !diags.HasErrors() {
+			result.ty, result.hasType = ty, true
+		}
+	}
+	if attribute, exists := content.Attributes["default"]; exists {
+		result.defaultValue, result.hasDefault = cty.DynamicVal, true
+		value, diags := attribute.Expr.Value(nil)
+		if !diags.HasErrors() {
+			result.defaultValue = value
+		}
+	}
+	return result
+}
+
+// readResource reads expressions of identifier attributes of a resource, nil is returned for unsupported types
+func (r *TerraformHCLReader) readResource(block *hcl.Block) *hclResource {
+	ty, name := block.Labels[0], block.Labels[1]
+	logger := logrus.WithFields(logrus.Fields{
+		"type": ty,
+		"name": name,
+	})
+
+	attributeNames, supported := identifierAttributes[ty]
+	if !supported {
+		logger.Debug("Ignored resource whose identifier is only known once applied")
+		return nil
+	}
+	schema := &hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "count"},
+			{Name: "for_each"},
+		},
+	}
+	for _, attributeName := range attributeNames {
+		schema.Attributes = append(schema.Attributes, hcl.AttributeSchema{Name: attributeName})
+	}
+	content, _, diags := block.Body.PartialContent(schema)
+	if diags.HasErrors() {
+		logger.Debugf("Ignored resource that cannot be read: %s", diags.Error())
+		return nil
+	}
+
+	res := &hclResource{Type: ty, Name: name, attributes: make(map[string]hcl.Expression)}
+	for attributeName, attribute := range content.Attributes {
+		res.attributes[attributeName] = attribute.Expr
+	}
+	return res
+}
+
+// identifiableResources returns resources with a single instance and every identifier attribute set
+func identifiableResources(resources []*hclResource) []*hclResource {
+	results := make([]*hclResource, 0, len(resources))
+ResourcesLoop:
+	for _, res := range resources {
+		logger := logrus.WithFields(logrus.Fields{
+			"type": res.Type,
+			"name": res.Name,
+		})
+
+		// Instances of a resource with count or for_each are only known once planned
+		if res.attributes["count"] != nil || res.attributes["for_each"] != nil {
+			logger.Debug("Ignored resource with several instances")
+			continue
+		}
+
+		for _, attributeName := range identifierAttributes[res.Type] {
+			expr, exists := res.attributes[attributeName]
+			if !exists {
+				logger.Debugf("Ignored resource without %s, its identifier is generated when applied", attributeName)
+				continue ResourcesLoop
+			}
+			res.identifiers = append(res.identifiers, expr)
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+// readVariableValues overrides default values of variables in the same order as terraform:
+// TF_VAR_ environment variables, terraform.tfvars, terraform.tfvars.json, then *.auto.tfvars
+// and *.auto.tfvars.json files in lexical order
+func (r *TerraformHCLReader) readVariableValues(mod *module) error {
+	for name := range mod.variables {
+		if raw, exists := os.LookupEnv("TF_VAR_" + name); exists {
+			value, err := parseEnvValue(name, raw, mod.variableTypes)
+			if err != nil {
+				return err
+			}
+			mod.variables[name] = value
+		}
+	}
+
+	files := []string{
+		filepath.Join(r.config.Path, "terraform.tfvars"),
+		filepath.Join(r.config.Path, "terraform.tfvars.json"),
+	}
+	autoFiles, err := filepath.Glob(filepath.Join(r.config.Path, "*.auto.tfvars"))
+	if err != nil {
+		return err
+	}
+	autoJSONFiles, err := filepath.Glob(filepath.Join(r.config.Path, "*.auto.tfvars.json"))
+	if err != nil {
+		return err
+	}
+	autoFiles = append(autoFiles, autoJSONFiles...)
+	sort.Strings(autoFiles)
+	files = append(files, autoFiles...)
+
+	parser := hclparse.NewParser()
+	for _, file := range files {
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			continue
+		}
+		hclFile, diags := parseFile(parser, file)
+		if diags.HasErrors() {
+			return errors.Errorf("Unable to parse variable values: %s", diags.Error())
+		}
+		attributes, diags := hclFile.Body.JustAttributes()
+		if diags.HasErrors() {
+			return errors.Errorf("Unable to parse variable values: %s", diags.Error())
+		}
+		for name, attribute := range attributes {
+			if _, declared := mod.variables[name]; !declared {
+				continue
+			}
+			value, diags := attribute.Expr.Value(nil)
+			if diags.HasErrors() {
+				return errors.Errorf("Unable to parse variable values: %s", diags.Error())
+			}
+			mod.variables[name] = value
+		}
+	}
+	return nil
+}
+
+// parseEnvValue parses a TF_VAR_ value as terraform does. Values of variables without type constraint
+// or with a primitive one are strings, other values are HCL expressions, e.g. ["a", "b"] for a list.
+func parseEnvValue(name, raw string, variableTypes map[string]cty.Type) (cty.Value, error) {
+	ty, hasType := variableTypes[name]
+	if !hasType || ty.IsPrimitiveType() {
+		return cty.StringVal(raw), nil
+	}
+
+	expr, diags := hclsyntax.ParseExpression([]byte(raw), "TF_VAR_"+name, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return cty.NilVal, errors.Errorf("Unable to parse variable values: %s", diags.Error())
+	}
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return cty.NilVal, errors.Errorf("Unable to parse variable values: %s", diags.Error())
+	}
+	return value, nil
+}
+
+// resolve evaluates locals and resource identifiers until none of them can be resolved anymore,
+// as they may refer to each other. Resources expose their identifier attributes and id to other ones.
+func (r *TerraformHCLReader) resolve(mod *module) {
+	functions := (&lang.Scope{BaseDir: r.config.Path, PureOnly: true}).Functions()
+	locals := make(map[string]cty.Value)
+	resources := make(map[string]map[string]cty.Value)
+
+	for {
+		ctx := &hcl.EvalContext{
+			Variables: map[string]cty.Value{
+				"var":   objectVal(mod.variables),
+				"local": objectVal(locals),
+			},
+			Functions: functions,
+		}
+		for ty, byName := range resources {
+			ctx.Variables[ty] = objectVal(byName)
+		}
+
+		resolved := false
+		for name, expr := range mod.locals {
+			if _, exists := locals[name]; exists {
+				continue
+			}
+			value, diags := expr.Value(ctx)
+			if diags.HasErrors() || !value.IsWhollyKnown() {
+				continue
+			}
+			locals[name] = value
+			resolved = true
+		}
+
+		for _, res := range mod.resources {
+			if res.id != "" {
+				continue
+			}
+			id, attributes, ok := evaluateIdentifier(ctx, res)
+			if !ok {
+				continue
+			}
+			res.id = id
+			attributes["id"] = cty.StringVal(id)
+			if resources[res.Type] == nil {
+				resources[res.Type] = make(map[string]cty.Value)
+			}
+			resources[res.Type][res.Name] = cty.ObjectVal(attributes)
+			resolved = true
+		}
+
+		if !resolved {
+			return
+		}
+	}
+}
+
+func evaluateIdentifier(ctx *hcl.EvalContext, res *hclResource) (string, map[string]cty.Value, bool) {
+	attributeNames := identifierAttributes[res.Type]
+	attributes := make(map[string]cty.Value, len(attributeNames)+1)
+	parts := make([]string, 0, len(res.identifiers))
+	for i, expr := range res.identifiers {
+		value, diags := expr.Value(ctx)
+		if diags.HasErrors() || !value.IsWhollyKnown() || value.IsNull() {
+			return "", nil, false
+		}
+		value, err := convert.Convert(value, cty.String)
+		if err != nil || value.AsString() == "" {
+			return "", nil, false
+		}
+		attributes[attributeNames[i]] = value
+		parts = append(parts, value.AsString())
+	}
+	return strings.Join(parts, ":"), attributes, true
+}
+
+func objectVal(values map[string]cty.Value) cty.Value {
+	if len(values) == 0 {
+		return cty.EmptyObjectVal
+	}
+	return cty.ObjectVal(values)
+}
+
+// configurationFiles returns terraform configuration files of a directory, and its override files apart
+func configurationFiles(dir string) ([]string, []string, error) {
+	files := make([]string, 0)
+	overrideFiles := make([]string, 0)
+	for _, pattern := range []string{"*.tf", "*.tf.json"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, match := range matches {
+			name := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(match), ".json"), ".tf")
+			if name == "override" || strings.HasSuffix(name, "_override") {
+				overrideFiles = append(overrideFiles, match)
+				continue
+			}
+			files = append(files, match)
+		}
+	}
+	sort.Strings(files)
+	sort.Strings(overrideFiles)
+	return files, overrideFiles, nil
+}
+
+func parseFile(parser *hclparse.Parser, file string) (*hcl.File, hcl.Diagnostics) {
+	if strings.HasSuffix(file, ".json") {
+		return parser.ParseJSONFile(file)
+	}
+	return parser.ParseHCLFile(file)
+}
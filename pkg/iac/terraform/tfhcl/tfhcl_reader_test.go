@@ -0,0 +1,134 @@
+package tfhcl
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+type typeFilterFunc func(ty string) bool
+
+func (f typeFilterFunc) IsTypeIgnored(ty string) bool {
+	return f(ty)
+}
+
+func TestTerraformHCLReader_Resources(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		env        map[string]string
+		typeFilter filter.TypeFilter
+		want       []resource.Resource
+		wantErr    string
+	}{
+		{
+			name: "test resources with identifiers resolved from variables and locals",
+			path: "testdata/valid",
+			want: []resource.Resource{
+				resource.SerializedResource{Id: "billing-prod-worker", Type: "aws_lambda_function"},
+				resource.SerializedResource{Id: "overridden-assets", Type: "aws_s3_bucket"},
+				resource.SerializedResource{Id: "overridden-assets", Type: "aws_s3_bucket_policy"},
+				resource.SerializedResource{Id: "billing-prod-worker", Type: "aws_iam_role"},
+				resource.SerializedResource{Id: "billing-prod-worker:worker", Type: "aws_iam_role_policy"},
+			},
+		},
+		{
+			name: "test variables from environment",
+			path: "testdata/valid",
+			env: map[string]string{
+				"TF_VAR_team":    "platform",
+				"TF_VAR_project": "overridden-by-tfvars",
+			},
+			want: []resource.Resource{
+				resource.SerializedResource{Id: "billing-prod-worker", Type: "aws_lambda_function"},
+				resource.SerializedResource{Id: "overridden-assets", Type: "aws_s3_bucket"},
+				resource.SerializedResource{Id: "overridden-assets", Type: "aws_s3_bucket_policy"},
+				resource.SerializedResource{Id: "billing-prod-worker", Type: "aws_iam_role"},
+				resource.SerializedResource{Id: "billing-prod-worker:worker", Type: "aws_iam_role_policy"},
+				resource.SerializedResource{Id: "platform-owner", Type: "aws_iam_user"},
+			},
+		},
+		{
+			name: "test override files are merged into the configuration",
+			path: "testdata/override",
+			want: []resource.Resource{
+				resource.SerializedResource{Id: "accounting-prod-worker", Type: "aws_iam_role"},
+				resource.SerializedResource{Id: "accounting-prod-owner", Type: "aws_iam_user"},
+			},
+		},
+		{
+			name: "test variables from environment are parsed according to their type",
+			path: "testdata/variables",
+			env: map[string]string{
+				"TF_VAR_buckets": `["first-assets", "second-assets"]`,
+				"TF_VAR_tags":    `{ team = "platform" }`,
+				"TF_VAR_index":   "3",
+				"TF_VAR_suffix":  `["literal"]`,
+			},
+			want: []resource.Resource{
+				resource.SerializedResource{Id: "first-assets", Type: "aws_s3_bucket"},
+				resource.SerializedResource{Id: "platform-assets", Type: "aws_s3_bucket"},
+				resource.SerializedResource{Id: "role-3", Type: "aws_iam_role"},
+				resource.SerializedResource{Id: "user-[\"literal\"]", Type: "aws_iam_user"},
+			},
+		},
+		{
+			name: "test invalid variable from environment",
+			path: "testdata/variables",
+			env: map[string]string{
+				"TF_VAR_buckets": `["first-assets",`,
+			},
+			wantErr: "Unable to parse variable values: TF_VAR_buckets:1,17-17: Invalid expression; Expected the start of an expression, but found an invalid expression token.",
+		},
+		{
+			name: "test ignored types are filtered out",
+			path: "testdata/valid",
+			typeFilter: typeFilterFunc(func(ty string) bool {
+				return ty != "aws_iam_role_policy"
+			}),
+			want: []resource.Resource{
+				resource.SerializedResource{Id: "billing-prod-worker:worker", Type: "aws_iam_role_policy"},
+			},
+		},
+		{
+			name:    "test invalid configuration",
+			path:    "testdata/invalid",
+			wantErr: "Unable to parse terraform configuration: testdata/invalid/main.tf:2,11-3,1: Invalid expression; Expected the start of an expression, but found an invalid expression token.",
+		},
+		{
+			name:    "test missing directory",
+			path:    "testdata/missing",
+			wantErr: "stat testdata/missing: no such file or directory",
+		},
+		{
+			name:    "test file instead of directory",
+			path:    "testdata/valid/main.tf",
+			wantErr: "testdata/valid/main.tf is not a directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				os.Setenv(key, value)
+				defer os.Unsetenv(key)
+			}
+
+			r, err := NewReader(config.SupplierConfig{Key: TerraformHCLSupplier, Path: tt.path}, tt.typeFilter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := r.Resources()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package middlewares
+
+import (
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/cloudskiff/driftctl/pkg/resource/aws"
+)
+
+// Some middlewares need remote resources of another type to process a resource type,
+// e.g. default internet gateway routes are detected using internet gateways and default VPCs
+var remoteTypeDependencies = map[resource.ResourceType][]resource.ResourceType{
+	aws.AwsRouteResourceType: {
+		aws.AwsInternetGatewayResourceType,
+		aws.AwsDefaultVpcResourceType,
+	},
+	aws.AwsInternetGatewayResourceType: {
+		aws.AwsDefaultVpcResourceType,
+	},
+	aws.AwsEipAssociationResourceType: {
+		aws.AwsNatGatewayResourceType,
+	},
+	aws.AwsIamPolicyAttachmentResourceType: {
+		aws.AwsIamRoleResourceType,
+	},
+	aws.AwsIamRolePolicyResourceType: {
+		aws.AwsIamRoleResourceType,
+	},
+}
+
+// Some state resources are created from resources of another type,
+// e.g. routes are expanded from route tables inline routes
+var stateTypeDependencies = map[resource.ResourceType][]resource.ResourceType{
+	aws.AwsIamPolicyAttachmentResourceType: {
+		aws.AwsIamRolePolicyAttachmentResourceType,
+		aws.AwsIamUserPolicyAttachmentResourceType,
+	},
+	aws.AwsRouteResourceType: {
+		aws.AwsRouteTableResourceType,
+		aws.AwsDefaultRouteTableResourceType,
+	},
+	aws.AwsEbsVolumeResourceType: {
+		aws.AwsInstanceResourceType,
+	},
+	aws.AwsInstanceResourceType: {
+		aws.AwsEipResourceType,
+		aws.AwsEipAssociationResourceType,
+	},
+	aws.AwsS3BucketPolicyResourceType: {
+		aws.AwsS3BucketResourceType,
+	},
+	aws.AwsSqsQueuePolicyResourceType: {
+		aws.AwsSqsQueueResourceType,
+	},
+	aws.AwsSnsTopicPolicyResourceType: {
+		aws.AwsSnsTopicResourceType,
+	},
+}
+
+// RequiredRemoteTypes returns given types along with every remote type middlewares need to process them
+func RequiredRemoteTypes(types []resource.ResourceType) []resource.ResourceType {
+	return requiredTypes(types, remoteTypeDependencies)
+}
+
+// RequiredStateTypes returns given types along with every state type middlewares need to process them
+func RequiredStateTypes(types []resource.ResourceType) []resource.ResourceType {
+	return requiredTypes(types, stateTypeDependencies)
+}
+
+func requiredTypes(types []resource.ResourceType, dependencies map[resource.ResourceType][]resource.ResourceType) []resource.ResourceType {
+	required := make([]resource.ResourceType, 0, len(types))
+	seen := make(map[resource.ResourceType]struct{}, len(types))
+
+	queue := append([]resource.ResourceType{}, types...)
+	for len(queue) > 0 {
+		ty := queue[0]
+		queue = queue[1:]
+		if _, exists := seen[ty]; exists {
+			continue
+		}
+		seen[ty] = struct{}{}
+		required = append(required, ty)
+		queue = append(queue, dependencies[ty]...)
+	}
+
+	return required
+}
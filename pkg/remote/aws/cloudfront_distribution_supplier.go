@@ -29,6 +29,12 @@ func NewCloudfrontDistributionSupplier(provider *AWSTerraformProvider) *Cloudfro
 	}
 }
 
+func (s *CloudfrontDistributionSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsCloudfrontDistributionResourceType,
+	}
+}
+
 func (s *CloudfrontDistributionSupplier) Resources() ([]resource.Resource, error) {
 	distributions, err := s.client.ListAllDistributions()
 	if err != nil {
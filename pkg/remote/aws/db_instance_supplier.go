@@ -29,6 +29,12 @@ func NewDBInstanceSupplier(provider *AWSTerraformProvider) *DBInstanceSupplier {
 	}
 }
 
+func (s *DBInstanceSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsDbInstanceResourceType,
+	}
+}
+
 func (s *DBInstanceSupplier) Resources() ([]resource.Resource, error) {
 
 	resourceList, err := s.client.ListAllDBInstances()
@@ -32,6 +32,12 @@ func NewDBSubnetGroupSupplier(provider *AWSTerraformProvider) *DBSubnetGroupSupp
 	}
 }
 
+func (s *DBSubnetGroupSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsDbSubnetGroupResourceType,
+	}
+}
+
 func (s *DBSubnetGroupSupplier) Resources() ([]resource.Resource, error) {
 
 	subnetGroups, err := s.client.ListAllDbSubnetGroups()
@@ -29,6 +29,12 @@ func NewDynamoDBTableSupplier(provider *AWSTerraformProvider) *DynamoDBTableSupp
 	}
 }
 
+func (s *DynamoDBTableSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsDynamodbTableResourceType,
+	}
+}
+
 func (s *DynamoDBTableSupplier) Resources() ([]resource.Resource, error) {
 	tables, err := s.repository.ListAllTables()
 	if err != nil {
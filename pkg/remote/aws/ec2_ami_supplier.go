@@ -31,6 +31,12 @@ func NewEC2AmiSupplier(provider *AWSTerraformProvider) *EC2AmiSupplier {
 	}
 }
 
+func (s *EC2AmiSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsAmiResourceType,
+	}
+}
+
 func (s *EC2AmiSupplier) Resources() ([]resource.Resource, error) {
 	images, err := s.client.ListAllImages()
 	if err != nil {
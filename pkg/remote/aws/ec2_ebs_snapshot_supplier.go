@@ -32,6 +32,12 @@ func NewEC2EbsSnapshotSupplier(provider *AWSTerraformProvider) *EC2EbsSnapshotSu
 	}
 }
 
+func (s *EC2EbsSnapshotSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsEbsSnapshotResourceType,
+	}
+}
+
 func (s *EC2EbsSnapshotSupplier) Resources() ([]resource.Resource, error) {
 	snapshots, err := s.client.ListAllSnapshots()
 	if err != nil {
@@ -32,6 +32,12 @@ func NewEC2EbsVolumeSupplier(provider *AWSTerraformProvider) *EC2EbsVolumeSuppli
 	}
 }
 
+func (s *EC2EbsVolumeSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsEbsVolumeResourceType,
+	}
+}
+
 func (s *EC2EbsVolumeSupplier) Resources() ([]resource.Resource, error) {
 	volumes, err := s.client.ListAllVolumes()
 	if err != nil {
@@ -29,6 +29,12 @@ func NewEC2EipAssociationSupplier(provider *AWSTerraformProvider) *EC2EipAssocia
 		terraform.NewParallelResourceReader(provider.Runner().SubRunner())}
 }
 
+func (s *EC2EipAssociationSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsEipAssociationResourceType,
+	}
+}
+
 func (s *EC2EipAssociationSupplier) Resources() ([]resource.Resource, error) {
 	associationIds, err := s.client.ListAllAddressesAssociation()
 	if err != nil {
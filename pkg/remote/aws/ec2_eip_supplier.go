@@ -32,6 +32,12 @@ func NewEC2EipSupplier(provider *AWSTerraformProvider) *EC2EipSupplier {
 	}
 }
 
+func (s *EC2EipSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsEipResourceType,
+	}
+}
+
 func (s *EC2EipSupplier) Resources() ([]resource.Resource, error) {
 	addresses, err := s.client.ListAllAddresses()
 	if err != nil {
@@ -31,6 +31,12 @@ func NewEC2InstanceSupplier(provider *AWSTerraformProvider) *EC2InstanceSupplier
 	}
 }
 
+func (s *EC2InstanceSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsInstanceResourceType,
+	}
+}
+
 func (s *EC2InstanceSupplier) Resources() ([]resource.Resource, error) {
 	instances, err := s.client.ListAllInstances()
 	if err != nil {
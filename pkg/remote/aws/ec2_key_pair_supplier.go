@@ -31,6 +31,12 @@ func NewEC2KeyPairSupplier(provider *AWSTerraformProvider) *EC2KeyPairSupplier {
 	}
 }
 
+func (s *EC2KeyPairSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsKeyPairResourceType,
+	}
+}
+
 func (s *EC2KeyPairSupplier) Resources() ([]resource.Resource, error) {
 	keyPairs, err := s.client.ListAllKeyPairs()
 	if err != nil {
@@ -30,6 +30,12 @@ func NewECRRepositorySupplier(provider *AWSTerraformProvider) *ECRRepositorySupp
 	}
 }
 
+func (r *ECRRepositorySupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsEcrRepositoryResourceType,
+	}
+}
+
 func (r *ECRRepositorySupplier) Resources() ([]resource.Resource, error) {
 	repositories, err := r.client.ListAllRepositories()
 	if err != nil {
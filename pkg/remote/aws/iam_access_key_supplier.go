@@ -31,6 +31,12 @@ func NewIamAccessKeySupplier(provider *AWSTerraformProvider) *IamAccessKeySuppli
 	}
 }
 
+func (s *IamAccessKeySupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsIamAccessKeyResourceType,
+	}
+}
+
 func (s *IamAccessKeySupplier) Resources() ([]resource.Resource, error) {
 	keys, err := listIamAccessKeys(s.client)
 	if err != nil {
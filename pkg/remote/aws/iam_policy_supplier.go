@@ -32,6 +32,12 @@ func NewIamPolicySupplier(provider *AWSTerraformProvider) *IamPolicySupplier {
 	}
 }
 
+func (s *IamPolicySupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsIamPolicyResourceType,
+	}
+}
+
 func (s *IamPolicySupplier) Resources() ([]resource.Resource, error) {
 	policies, err := listIamPolicies(s.client)
 	if err != nil {
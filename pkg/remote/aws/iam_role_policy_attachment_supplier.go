@@ -31,6 +31,12 @@ func NewIamRolePolicyAttachmentSupplier(provider *AWSTerraformProvider) *IamRole
 	}
 }
 
+func (s *IamRolePolicyAttachmentSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsIamPolicyAttachmentResourceType,
+	}
+}
+
 func (s *IamRolePolicyAttachmentSupplier) Resources() ([]resource.Resource, error) {
 	roles, err := listIamRoles(s.client, resourceaws.AwsIamRolePolicyAttachmentResourceType)
 	if err != nil {
@@ -33,6 +33,12 @@ func NewIamRolePolicySupplier(provider *AWSTerraformProvider) *IamRolePolicySupp
 	}
 }
 
+func (s *IamRolePolicySupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsIamRolePolicyResourceType,
+	}
+}
+
 func (s *IamRolePolicySupplier) Resources() ([]resource.Resource, error) {
 	policies, err := listIamRolePolicies(s.client, resourceaws.AwsIamRolePolicyResourceType)
 	if err != nil {
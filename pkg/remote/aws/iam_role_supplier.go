@@ -45,6 +45,12 @@ func awsIamRoleShouldBeIgnored(roleName string) bool {
 	return ok
 }
 
+func (s *IamRoleSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsIamRoleResourceType,
+	}
+}
+
 func (s *IamRoleSupplier) Resources() ([]resource.Resource, error) {
 	roles, err := listIamRoles(s.client, resourceaws.AwsIamRoleResourceType)
 	if err != nil {
@@ -31,6 +31,12 @@ func NewIamUserPolicyAttachmentSupplier(provider *AWSTerraformProvider) *IamUser
 	}
 }
 
+func (s *IamUserPolicyAttachmentSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsIamPolicyAttachmentResourceType,
+	}
+}
+
 func (s *IamUserPolicyAttachmentSupplier) Resources() ([]resource.Resource, error) {
 	users, err := listIamUsers(s.client, resourceaws.AwsIamUserPolicyAttachmentResourceType)
 	if err != nil {
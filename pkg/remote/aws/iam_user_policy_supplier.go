@@ -33,6 +33,12 @@ func NewIamUserPolicySupplier(provider *AWSTerraformProvider) *IamUserPolicySupp
 	}
 }
 
+func (s *IamUserPolicySupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsIamUserPolicyResourceType,
+	}
+}
+
 func (s *IamUserPolicySupplier) Resources() ([]resource.Resource, error) {
 	users, err := listIamUsers(s.client, resourceaws.AwsIamUserPolicyResourceType)
 	if err != nil {
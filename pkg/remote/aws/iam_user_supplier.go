@@ -31,6 +31,12 @@ func NewIamUserSupplier(provider *AWSTerraformProvider) *IamUserSupplier {
 	}
 }
 
+func (s *IamUserSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsIamUserResourceType,
+	}
+}
+
 func (s *IamUserSupplier) Resources() ([]resource.Resource, error) {
 	users, err := listIamUsers(s.client, resourceaws.AwsIamUserResourceType)
 	if err != nil {
@@ -29,6 +29,12 @@ func NewInternetGatewaySupplier(provider *AWSTerraformProvider) *InternetGateway
 	}
 }
 
+func (s *InternetGatewaySupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsInternetGatewayResourceType,
+	}
+}
+
 func (s *InternetGatewaySupplier) Resources() ([]resource.Resource, error) {
 	internetGateways, err := listInternetGateways(s.client)
 	if err != nil {
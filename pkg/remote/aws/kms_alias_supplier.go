@@ -30,6 +30,12 @@ func NewKMSAliasSupplier(provider *AWSTerraformProvider) *KMSAliasSupplier {
 	}
 }
 
+func (s *KMSAliasSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsKmsAliasResourceType,
+	}
+}
+
 func (s *KMSAliasSupplier) Resources() ([]resource.Resource, error) {
 	aliases, err := s.client.ListAllAliases()
 	if err != nil {
@@ -30,6 +30,12 @@ func NewKMSKeySupplier(provider *AWSTerraformProvider) *KMSKeySupplier {
 	}
 }
 
+func (s *KMSKeySupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsKmsKeyResourceType,
+	}
+}
+
 func (s *KMSKeySupplier) Resources() ([]resource.Resource, error) {
 	keys, err := s.client.ListAllKeys()
 	if err != nil {
@@ -29,6 +29,12 @@ func NewLambdaEventSourceMappingSupplier(provider *AWSTerraformProvider) *Lambda
 	}
 }
 
+func (s *LambdaEventSourceMappingSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsLambdaEventSourceMappingResourceType,
+	}
+}
+
 func (s *LambdaEventSourceMappingSupplier) Resources() ([]resource.Resource, error) {
 	functions, err := s.client.ListAllLambdaEventSourceMappings()
 	if err != nil {
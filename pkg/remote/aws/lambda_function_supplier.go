@@ -29,6 +29,12 @@ func NewLambdaFunctionSupplier(provider *AWSTerraformProvider) *LambdaFunctionSu
 	}
 }
 
+func (s *LambdaFunctionSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsLambdaFunctionResourceType,
+	}
+}
+
 func (s *LambdaFunctionSupplier) Resources() ([]resource.Resource, error) {
 	functions, err := s.client.ListAllLambdaFunctions()
 	if err != nil {
@@ -29,6 +29,12 @@ func NewNatGatewaySupplier(provider *AWSTerraformProvider) *NatGatewaySupplier {
 	}
 }
 
+func (s *NatGatewaySupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsNatGatewayResourceType,
+	}
+}
+
 func (s *NatGatewaySupplier) Resources() ([]resource.Resource, error) {
 
 	retrievedNatGateways, err := listNatGateways(s.client)
@@ -30,6 +30,12 @@ func NewRoute53HealthCheckSupplier(provider *AWSTerraformProvider) *Route53Healt
 	}
 }
 
+func (s *Route53HealthCheckSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsRoute53HealthCheckResourceType,
+	}
+}
+
 func (s *Route53HealthCheckSupplier) Resources() ([]resource.Resource, error) {
 	healthChecks, err := s.client.ListAllHealthChecks()
 	if err != nil {
@@ -32,6 +32,12 @@ func NewRoute53RecordSupplier(provider *AWSTerraformProvider) *Route53RecordSupp
 		terraform.NewParallelResourceReader(provider.Runner().SubRunner())}
 }
 
+func (s *Route53RecordSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsRoute53RecordResourceType,
+	}
+}
+
 func (s *Route53RecordSupplier) Resources() ([]resource.Resource, error) {
 
 	zones, err := s.listZones()
@@ -34,6 +34,12 @@ func NewRoute53ZoneSupplier(provider *AWSTerraformProvider) *Route53ZoneSupplier
 	}
 }
 
+func (s *Route53ZoneSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsRoute53ZoneResourceType,
+	}
+}
+
 func (s *Route53ZoneSupplier) Resources() ([]resource.Resource, error) {
 
 	zones, err := s.client.ListAllZones()
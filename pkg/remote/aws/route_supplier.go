@@ -29,6 +29,12 @@ func NewRouteSupplier(provider *AWSTerraformProvider) *RouteSupplier {
 	}
 }
 
+func (s *RouteSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsRouteResourceType,
+	}
+}
+
 func (s *RouteSupplier) Resources() ([]resource.Resource, error) {
 
 	routeTables, err := listRouteTables(s.client, aws.AwsRouteResourceType)
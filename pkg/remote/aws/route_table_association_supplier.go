@@ -29,6 +29,12 @@ func NewRouteTableAssociationSupplier(provider *AWSTerraformProvider) *RouteTabl
 	}
 }
 
+func (s *RouteTableAssociationSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsRouteTableAssociationResourceType,
+	}
+}
+
 func (s *RouteTableAssociationSupplier) Resources() ([]resource.Resource, error) {
 
 	tables, err := listRouteTables(s.client, aws.AwsRouteTableAssociationResourceType)
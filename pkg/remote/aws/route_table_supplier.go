@@ -37,6 +37,13 @@ func NewRouteTableSupplier(provider *AWSTerraformProvider) *RouteTableSupplier {
 	}
 }
 
+func (s *RouteTableSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsDefaultRouteTableResourceType,
+		aws.AwsRouteTableResourceType,
+	}
+}
+
 func (s *RouteTableSupplier) Resources() ([]resource.Resource, error) {
 
 	results, err := listRouteTables(s.client, aws.AwsRouteTableResourceType)
@@ -33,6 +33,12 @@ func NewS3BucketAnalyticSupplier(provider *AWSTerraformProvider, repository repo
 	}
 }
 
+func (s *S3BucketAnalyticSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsS3BucketAnalyticsConfigurationResourceType,
+	}
+}
+
 func (s *S3BucketAnalyticSupplier) Resources() ([]resource.Resource, error) {
 	buckets, err := s.repository.ListAllBuckets()
 	if err != nil {
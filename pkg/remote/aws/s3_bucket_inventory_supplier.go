@@ -33,6 +33,12 @@ func NewS3BucketInventorySupplier(provider *AWSTerraformProvider, repository rep
 	}
 }
 
+func (s *S3BucketInventorySupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsS3BucketInventoryResourceType,
+	}
+}
+
 func (s *S3BucketInventorySupplier) Resources() ([]resource.Resource, error) {
 	buckets, err := s.repository.ListAllBuckets()
 	if err != nil {
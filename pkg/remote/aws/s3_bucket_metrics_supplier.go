@@ -33,6 +33,12 @@ func NewS3BucketMetricSupplier(provider *AWSTerraformProvider, repository reposi
 	}
 }
 
+func (s *S3BucketMetricSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsS3BucketMetricResourceType,
+	}
+}
+
 func (s *S3BucketMetricSupplier) Resources() ([]resource.Resource, error) {
 	buckets, err := s.repository.ListAllBuckets()
 	if err != nil {
@@ -30,6 +30,12 @@ func NewS3BucketNotificationSupplier(provider *AWSTerraformProvider, repository
 	}
 }
 
+func (s *S3BucketNotificationSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsS3BucketNotificationResourceType,
+	}
+}
+
 func (s *S3BucketNotificationSupplier) Resources() ([]resource.Resource, error) {
 	buckets, err := s.repository.ListAllBuckets()
 	if err != nil {
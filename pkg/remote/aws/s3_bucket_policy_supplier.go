@@ -30,6 +30,12 @@ func NewS3BucketPolicySupplier(provider *AWSTerraformProvider, repository reposi
 	}
 }
 
+func (s *S3BucketPolicySupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsS3BucketPolicyResourceType,
+	}
+}
+
 func (s *S3BucketPolicySupplier) Resources() ([]resource.Resource, error) {
 	buckets, err := s.repository.ListAllBuckets()
 	if err != nil {
@@ -33,6 +33,12 @@ func NewS3BucketSupplier(provider *AWSTerraformProvider, repository repository.S
 	}
 }
 
+func (s *S3BucketSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsS3BucketResourceType,
+	}
+}
+
 func (s *S3BucketSupplier) Resources() ([]resource.Resource, error) {
 	buckets, err := s.repository.ListAllBuckets()
 	if err != nil {
@@ -30,6 +30,12 @@ func NewSNSTopicPolicySupplier(provider *AWSTerraformProvider) *SNSTopicPolicySu
 	}
 }
 
+func (s *SNSTopicPolicySupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsSnsTopicPolicyResourceType,
+	}
+}
+
 func (s *SNSTopicPolicySupplier) Resources() ([]resource.Resource, error) {
 	topics, err := s.client.ListAllTopics()
 	if err != nil {
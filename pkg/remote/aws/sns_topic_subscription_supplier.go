@@ -51,6 +51,12 @@ func NewSNSTopicSubscriptionSupplier(provider *AWSTerraformProvider, a alerter.A
 	}
 }
 
+func (s *SNSTopicSubscriptionSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsSnsTopicSubscriptionResourceType,
+	}
+}
+
 func (s *SNSTopicSubscriptionSupplier) Resources() ([]resource.Resource, error) {
 	subscriptions, err := s.client.ListAllSubscriptions()
 	if err != nil {
@@ -30,6 +30,12 @@ func NewSNSTopicSupplier(provider *AWSTerraformProvider) *SNSTopicSupplier {
 	}
 }
 
+func (s *SNSTopicSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsSnsTopicResourceType,
+	}
+}
+
 func (s *SNSTopicSupplier) Resources() ([]resource.Resource, error) {
 	topics, err := s.client.ListAllTopics()
 	if err != nil {
@@ -28,6 +28,12 @@ func NewSqsQueuePolicySupplier(provider *AWSTerraformProvider) *SqsQueuePolicySu
 	}
 }
 
+func (s *SqsQueuePolicySupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsSqsQueuePolicyResourceType,
+	}
+}
+
 func (s *SqsQueuePolicySupplier) Resources() ([]resource.Resource, error) {
 	queues, err := s.client.ListAllQueues()
 	if err != nil {
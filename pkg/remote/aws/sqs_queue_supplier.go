@@ -28,6 +28,12 @@ func NewSqsQueueSupplier(provider *AWSTerraformProvider) *SqsQueueSupplier {
 	}
 }
 
+func (s *SqsQueueSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsSqsQueueResourceType,
+	}
+}
+
 func (s *SqsQueueSupplier) Resources() ([]resource.Resource, error) {
 	queues, err := s.client.ListAllQueues()
 	if err != nil {
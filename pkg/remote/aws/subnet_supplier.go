@@ -36,6 +36,13 @@ func NewSubnetSupplier(provider *AWSTerraformProvider) *SubnetSupplier {
 	}
 }
 
+func (s *SubnetSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsDefaultSubnetResourceType,
+		aws.AwsSubnetResourceType,
+	}
+}
+
 func (s *SubnetSupplier) Resources() ([]resource.Resource, error) {
 	input := ec2.DescribeSubnetsInput{}
 	var subnets []*ec2.Subnet
@@ -36,6 +36,13 @@ func NewVPCSecurityGroupSupplier(provider *AWSTerraformProvider) *VPCSecurityGro
 	}
 }
 
+func (s *VPCSecurityGroupSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsDefaultSecurityGroupResourceType,
+		resourceaws.AwsSecurityGroupResourceType,
+	}
+}
+
 func (s *VPCSecurityGroupSupplier) Resources() ([]resource.Resource, error) {
 	securityGroups, defaultSecurityGroups, err := listSecurityGroups(s.client)
 	if err != nil {
@@ -36,6 +36,13 @@ func NewVPCSupplier(provider *AWSTerraformProvider) *VPCSupplier {
 	}
 }
 
+func (s *VPCSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsDefaultVpcResourceType,
+		aws.AwsVpcResourceType,
+	}
+}
+
 func (s *VPCSupplier) Resources() ([]resource.Resource, error) {
 	input := ec2.DescribeVpcsInput{}
 	var VPCs []*ec2.Vpc
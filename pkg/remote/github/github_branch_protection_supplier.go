@@ -27,6 +27,12 @@ func NewGithubBranchProtectionSupplier(provider *GithubTerraformProvider, reposi
 	}
 }
 
+func (s GithubBranchProtectionSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourcegithub.GithubBranchProtectionResourceType,
+	}
+}
+
 func (s GithubBranchProtectionSupplier) Resources() ([]resource.Resource, error) {
 
 	resourceList, err := s.repository.ListBranchProtection()
@@ -27,6 +27,12 @@ func NewGithubMembershipSupplier(provider *GithubTerraformProvider, repository G
 	}
 }
 
+func (s GithubMembershipSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourcegithub.GithubMembershipResourceType,
+	}
+}
+
 func (s GithubMembershipSupplier) Resources() ([]resource.Resource, error) {
 
 	resourceList, err := s.repository.ListMembership()
@@ -27,6 +27,12 @@ func NewGithubRepositorySupplier(provider *GithubTerraformProvider, repository G
 	}
 }
 
+func (s GithubRepositorySupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourcegithub.GithubRepositoryResourceType,
+	}
+}
+
 func (s GithubRepositorySupplier) Resources() ([]resource.Resource, error) {
 
 	resourceList, err := s.repository.ListRepositories()
@@ -27,6 +27,12 @@ func NewGithubTeamMembershipSupplier(provider *GithubTerraformProvider, reposito
 	}
 }
 
+func (s GithubTeamMembershipSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourcegithub.GithubTeamMembershipResourceType,
+	}
+}
+
 func (s GithubTeamMembershipSupplier) Resources() ([]resource.Resource, error) {
 	resourceList, err := s.repository.ListTeamMemberships()
 	if err != nil {
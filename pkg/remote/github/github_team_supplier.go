@@ -29,6 +29,12 @@ func NewGithubTeamSupplier(provider *GithubTerraformProvider, repository GithubR
 	}
 }
 
+func (s GithubTeamSupplier) SuppliedTypes() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourcegithub.GithubTeamResourceType,
+	}
+}
+
 func (s GithubTeamSupplier) Resources() ([]resource.Resource, error) {
 
 	resourceList, err := s.repository.ListTeams()
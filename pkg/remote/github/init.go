@@ -35,3 +35,14 @@ func Init(alerter *alerter.Alerter, providerLibrary *terraform.ProviderLibrary,
 
 	return nil
 }
+
+// LoadSchema starts the terraform provider to read resource schemas, without configuring credentials
+func LoadSchema(providerLibrary *terraform.ProviderLibrary, progress output.Progress) error {
+	provider, err := NewGithubTerraformProvider(progress)
+	if err != nil {
+		return err
+	}
+	// Added before starting so the library cleanup stops it
+	providerLibrary.AddProvider(terraform.GITHUB, provider)
+	return provider.LoadSchema()
+}
@@ -35,6 +35,19 @@ func Activate(remote string, alerter *alerter.Alerter, providerLibrary *terrafor
 	}
 }
 
+// LoadSchema adds the provider of a remote to the library with its resource schemas only,
+// no credentials are needed but resources cannot be read
+func LoadSchema(remote string, providerLibrary *terraform.ProviderLibrary, progress output.Progress) error {
+	switch remote {
+	case aws.RemoteAWSTerraform:
+		return aws.LoadSchema(providerLibrary, progress)
+	case github.RemoteGithubTerraform:
+		return github.LoadSchema(providerLibrary, progress)
+	default:
+		return errors.Errorf("unsupported remote '%s'", remote)
+	}
+}
+
 func GetSupportedRemotes() []string {
 	return supportedRemotes
 }
@@ -87,11 +87,18 @@ func (p *TerraformProvider) Runner() *parallel.ParallelRunner {
 	return p.runner
 }
 
-func (p *TerraformProvider) configure(alias string) error {
+// LoadSchema starts the provider to read its resource schemas.
+// The provider is not configured, no credentials are needed but resources cannot be read.
+func (p *TerraformProvider) LoadSchema() error {
+	_, err := p.start(p.Config.DefaultAlias)
+	return err
+}
 
+// start launches the gRPC client of an alias if needed, and returns the provider schema
+func (p *TerraformProvider) start(alias string) (*providers.GetSchemaResponse, error) {
 	providerPath, err := p.providerInstaller.Install()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if p.grpcProviders[alias] == nil {
@@ -103,7 +110,7 @@ func (p *TerraformProvider) configure(alias string) error {
 		})
 
 		if err != nil {
-			return err
+			return nil, err
 		}
 		p.grpcProviders[alias] = GRPCProvider
 	}
@@ -112,6 +119,14 @@ func (p *TerraformProvider) configure(alias string) error {
 	if p.schemas == nil {
 		p.schemas = schema.ResourceTypes
 	}
+	return &schema, nil
+}
+
+func (p *TerraformProvider) configure(alias string) error {
+	schema, err := p.start(alias)
+	if err != nil {
+		return err
+	}
 	configType := schema.Provider.Block.ImpliedType()
 	val, err := gocty.ToCtyValue(p.Config.GetProviderConfig(alias), configType)
 	if err != nil {
@@ -22,6 +22,16 @@ func (r *ChainSupplier) AddSupplier(supplier Supplier) {
 	r.suppliers = append(r.suppliers, supplier)
 }
 
+// IsFieldIgnored tells whether a field is ignored by one of the chained suppliers
+func (r *ChainSupplier) IsFieldIgnored(res Resource, path []string) bool {
+	for _, supplier := range r.suppliers {
+		if fieldFilter, ok := supplier.(FieldFilterSupplier); ok && fieldFilter.IsFieldIgnored(res, path) {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *ChainSupplier) Resources() ([]Resource, error) {
 
 	for _, supplier := range r.suppliers {
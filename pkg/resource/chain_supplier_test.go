@@ -89,3 +89,19 @@ func TestChainSupplier_Resources_WithError(t *testing.T) {
 	assert.Nil(res)
 	assert.Equal("error from another supplier", err.Error())
 }
+
+func TestChainSupplier_IsFieldIgnored(t *testing.T) {
+	res := testresource.FakeResource{Id: "fake-resource"}
+
+	fieldFilterSupplier := mocks.FieldFilterSupplier{}
+	fieldFilterSupplier.On("IsFieldIgnored", res, []string{"Unknown"}).Return(true)
+	fieldFilterSupplier.On("IsFieldIgnored", res, []string{"Known"}).Return(false)
+
+	chain := resource.NewChainSupplier()
+	chain.AddSupplier(&mocks.Supplier{})
+	chain.AddSupplier(&fieldFilterSupplier)
+
+	assert.True(t, chain.IsFieldIgnored(res, []string{"Unknown"}))
+	assert.False(t, chain.IsFieldIgnored(res, []string{"Known"}))
+	fieldFilterSupplier.AssertExpectations(t)
+}
@@ -2,6 +2,7 @@ package resource
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 
 	"github.com/zclconf/go-cty/cty"
@@ -38,6 +39,11 @@ func (u SerializedResource) CtyValue() *cty.Value {
 	return &cty.NilVal
 }
 
+// ReferencedResource is a resource read by a data source, it exists but is managed outside of the scanned IaC
+type ReferencedResource struct {
+	SerializedResource
+}
+
 func (s *SerializableResource) UnmarshalJSON(bytes []byte) error {
 	var res SerializedResource
 
@@ -61,6 +67,11 @@ func IsSameResource(rRs, lRs Resource) bool {
 	return rRs.TerraformType() == lRs.TerraformType() && rRs.TerraformId() == lRs.TerraformId()
 }
 
+// Key identifies a resource by its type and id, e.g. aws_s3_bucket.my-bucket
+func Key(res Resource) string {
+	return fmt.Sprintf("%s.%s", res.TerraformType(), res.TerraformId())
+}
+
 func Sort(res []Resource) []Resource {
 	sort.SliceStable(res, func(i, j int) bool {
 		if res[i].TerraformType() != res[j].TerraformType() {
@@ -9,3 +9,16 @@ type StoppableSupplier interface {
 	Supplier
 	Stop()
 }
+
+// FieldFilterSupplier is a Supplier returning resources with fields that must not be compared
+// with remote resources, e.g. attributes of a plan unknown until apply
+type FieldFilterSupplier interface {
+	Supplier
+	IsFieldIgnored(res Resource, path []string) bool
+}
+
+// TypedSupplier is a Supplier able to tell which resource types it returns
+type TypedSupplier interface {
+	Supplier
+	SuppliedTypes() []ResourceType
+}
@@ -2,6 +2,7 @@ package pkg
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/cloudskiff/driftctl/pkg/remote"
 	"github.com/pkg/errors"
@@ -10,6 +11,8 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/cloudskiff/driftctl/pkg/alerter"
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/middlewares"
 	"github.com/cloudskiff/driftctl/pkg/resource"
 )
 
@@ -27,6 +30,54 @@ func NewScanner(resourceSuppliers []resource.Supplier, alerter *alerter.Alerter)
 	}
 }
 
+// FilterSuppliers removes suppliers whose every resource type is ignored by type filters.
+// Suppliers not able to tell which types they return are always kept, as are suppliers
+// of types needed by middlewares to process the remaining types.
+func FilterSuppliers(suppliers []resource.Supplier, typeFilters []filter.TypeFilter) []resource.Supplier {
+	wantedTypes := make([]resource.ResourceType, 0)
+	for _, supplier := range suppliers {
+		typedSupplier, ok := supplier.(resource.TypedSupplier)
+		if !ok {
+			continue
+		}
+		for _, ty := range typedSupplier.SuppliedTypes() {
+			if !isTypeIgnored(ty.String(), typeFilters) {
+				wantedTypes = append(wantedTypes, ty)
+			}
+		}
+	}
+
+	requiredTypes := make(map[resource.ResourceType]struct{})
+	for _, ty := range middlewares.RequiredRemoteTypes(wantedTypes) {
+		requiredTypes[ty] = struct{}{}
+	}
+
+	filteredSuppliers := make([]resource.Supplier, 0, len(suppliers))
+	for _, supplier := range suppliers {
+		typedSupplier, ok := supplier.(resource.TypedSupplier)
+		if !ok {
+			filteredSuppliers = append(filteredSuppliers, supplier)
+			continue
+		}
+		isRequired := false
+		for _, ty := range typedSupplier.SuppliedTypes() {
+			if _, exists := requiredTypes[ty]; exists {
+				isRequired = true
+				break
+			}
+		}
+		if !isRequired {
+			logrus.WithFields(logrus.Fields{
+				"supplier": fmt.Sprintf("%T", supplier),
+			}).Debug("Skipped supplier as all its resource types are filtered out")
+			continue
+		}
+		filteredSuppliers = append(filteredSuppliers, supplier)
+	}
+
+	return filteredSuppliers
+}
+
 func (s *Scanner) Resources() ([]resource.Resource, error) {
 	for _, resourceProvider := range s.resourceSuppliers {
 		supplier := resourceProvider
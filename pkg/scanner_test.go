@@ -0,0 +1,80 @@
+package pkg_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg"
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/cloudskiff/driftctl/pkg/resource/aws"
+)
+
+type fakeTypedSupplier struct {
+	types []resource.ResourceType
+}
+
+func (s *fakeTypedSupplier) SuppliedTypes() []resource.ResourceType {
+	return s.types
+}
+
+func (s *fakeTypedSupplier) Resources() ([]resource.Resource, error) {
+	return []resource.Resource{}, nil
+}
+
+func TestFilterSuppliers(t *testing.T) {
+	bucketSupplier := &fakeTypedSupplier{types: []resource.ResourceType{aws.AwsS3BucketResourceType}}
+	routeSupplier := &fakeTypedSupplier{types: []resource.ResourceType{aws.AwsRouteResourceType}}
+	igwSupplier := &fakeTypedSupplier{types: []resource.ResourceType{aws.AwsInternetGatewayResourceType}}
+	vpcSupplier := &fakeTypedSupplier{types: []resource.ResourceType{aws.AwsDefaultVpcResourceType, aws.AwsVpcResourceType}}
+	userSupplier := &fakeTypedSupplier{types: []resource.ResourceType{aws.AwsIamUserResourceType}}
+	untypedSupplier := &resource.MockSupplier{}
+
+	suppliers := []resource.Supplier{
+		bucketSupplier,
+		routeSupplier,
+		igwSupplier,
+		vpcSupplier,
+		userSupplier,
+		untypedSupplier,
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		want []resource.Supplier
+	}{
+		{
+			name: "test no type excluded",
+			expr: "Attr.Tags.Terraform == 'true'",
+			want: suppliers,
+		},
+		{
+			name: "test single type",
+			expr: "Type == 'aws_s3_bucket'",
+			want: []resource.Supplier{bucketSupplier, untypedSupplier},
+		},
+		{
+			name: "test middleware dependencies are kept",
+			expr: "Type == 'aws_route'",
+			want: []resource.Supplier{routeSupplier, igwSupplier, vpcSupplier, untypedSupplier},
+		},
+		{
+			name: "test supplier kept when one of its types is wanted",
+			expr: "Type == 'aws_vpc'",
+			want: []resource.Supplier{vpcSupplier, untypedSupplier},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pkg.FilterSuppliers(suppliers, []filter.TypeFilter{filter.NewExpressionTypeFilter(tt.expr)})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("test type selection", func(t *testing.T) {
+		got := pkg.FilterSuppliers(suppliers, []filter.TypeFilter{filter.NewTypeSelection([]string{"aws_route", "aws_iam_*"}, []string{"aws_iam_user"})})
+		assert.Equal(t, []resource.Supplier{routeSupplier, igwSupplier, vpcSupplier, untypedSupplier}, got)
+	})
+}
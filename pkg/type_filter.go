@@ -0,0 +1,49 @@
+package pkg
+
+import (
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac"
+	"github.com/cloudskiff/driftctl/pkg/middlewares"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+// StateTypeFilter ignores every state resource type that is not required to compute results
+type StateTypeFilter struct {
+	types map[resource.ResourceType]struct{}
+}
+
+// NewStateTypeFilter requires state types not ignored by any of the given filters,
+// along with types needed to build them
+func NewStateTypeFilter(typeFilters ...filter.TypeFilter) *StateTypeFilter {
+	wantedTypes := make([]resource.ResourceType, 0)
+	for _, ty := range iac.SupportedResourceTypes() {
+		if !isTypeIgnored(ty, typeFilters) {
+			wantedTypes = append(wantedTypes, resource.ResourceType(ty))
+		}
+	}
+
+	types := make(map[resource.ResourceType]struct{})
+	for _, ty := range middlewares.RequiredStateTypes(wantedTypes) {
+		types[ty] = struct{}{}
+	}
+
+	return &StateTypeFilter{types: types}
+}
+
+func (f *StateTypeFilter) IsTypeRequired(ty resource.ResourceType) bool {
+	_, exists := f.types[ty]
+	return exists
+}
+
+func (f *StateTypeFilter) IsTypeIgnored(ty string) bool {
+	return !f.IsTypeRequired(resource.ResourceType(ty))
+}
+
+func isTypeIgnored(ty string, typeFilters []filter.TypeFilter) bool {
+	for _, typeFilter := range typeFilters {
+		if typeFilter.IsTypeIgnored(ty) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package pkg_test
+
+import (
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg"
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/cloudskiff/driftctl/pkg/resource/aws"
+)
+
+func TestNewStateTypeFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		only []string
+		want map[resource.ResourceType]bool
+	}{
+		{
+			name: "test routes require route tables",
+			only: []string{"aws_route"},
+			want: map[resource.ResourceType]bool{
+				aws.AwsRouteResourceType:             true,
+				aws.AwsRouteTableResourceType:        true,
+				aws.AwsDefaultRouteTableResourceType: true,
+				aws.AwsInternetGatewayResourceType:   false,
+				aws.AwsS3BucketResourceType:          false,
+			},
+		},
+		{
+			name: "test instances require eips and eip associations",
+			only: []string{"aws_instance"},
+			want: map[resource.ResourceType]bool{
+				aws.AwsInstanceResourceType:       true,
+				aws.AwsEipResourceType:            true,
+				aws.AwsEipAssociationResourceType: true,
+				aws.AwsEbsVolumeResourceType:      false,
+				aws.AwsS3BucketResourceType:       false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := pkg.NewStateTypeFilter(filter.NewTypeSelection(tt.only, nil))
+			for ty, want := range tt.want {
+				if got := f.IsTypeRequired(ty); got != want {
+					t.Errorf("IsTypeRequired(%s) got = %v, want %v", ty, got, want)
+				}
+			}
+		})
+	}
+}